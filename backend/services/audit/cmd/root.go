@@ -0,0 +1,48 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/urfave/cli/v2"
+)
+
+func GetCommands() cli.Commands {
+	return []*cli.Command{
+		Server(),
+	}
+}
+
+func Run() error {
+	app := &cli.App{
+		Name:        "onlyoffice:audit",
+		Description: "Description",
+		Authors: []*cli.Author{
+			{
+				Name:  "Ascensio Systems SIA",
+				Email: "support@onlyoffice.com",
+			},
+		},
+		HideVersion: true,
+		Commands:    GetCommands(),
+	}
+
+	return app.Run(os.Args)
+}
@@ -0,0 +1,111 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/core/port"
+	"github.com/google/uuid"
+)
+
+type memoryAuditAdapter struct {
+	mu  sync.RWMutex
+	kvs map[string][]byte
+}
+
+func NewMemoryAuditAdapter() port.AuditServiceAdapter {
+	return &memoryAuditAdapter{
+		kvs: make(map[string][]byte),
+	}
+}
+
+func (m *memoryAuditAdapter) InsertEvent(ctx context.Context, event domain.Event) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	event.ID = uuid.NewString()
+	buffer, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.kvs[event.ID] = buffer
+
+	return nil
+}
+
+func (m *memoryAuditAdapter) SelectEvents(ctx context.Context, filter domain.EventFilter) ([]domain.Event, int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	events := make([]domain.Event, 0)
+	for _, buffer := range m.kvs {
+		var event domain.Event
+		if err := json.Unmarshal(buffer, &event); err != nil {
+			return nil, 0, err
+		}
+
+		if filter.Matches(event) {
+			events = append(events, event)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].CreatedAt.After(events[j].CreatedAt)
+	})
+
+	total := int64(len(events))
+	start := (filter.Page - 1) * filter.Limit
+	if start >= len(events) {
+		return []domain.Event{}, total, nil
+	}
+
+	end := start + filter.Limit
+	if end > len(events) {
+		end = len(events)
+	}
+
+	return events[start:end], total, nil
+}
+
+func (m *memoryAuditAdapter) DeleteEvents(ctx context.Context, cid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, buffer := range m.kvs {
+		var event domain.Event
+		if err := json.Unmarshal(buffer, &event); err != nil {
+			return err
+		}
+
+		if event.CompanyID == cid {
+			delete(m.kvs, id)
+		}
+	}
+
+	return nil
+}
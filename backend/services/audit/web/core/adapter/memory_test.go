@@ -0,0 +1,90 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryAdapter(t *testing.T) {
+	adapter := NewMemoryAuditAdapter()
+	now := time.Now()
+
+	t.Run("save events", func(t *testing.T) {
+		for idx, deal := range []string{"1", "1", "2"} {
+			assert.NoError(t, adapter.InsertEvent(context.Background(), domain.Event{
+				Type:      domain.EventConfigBuilt,
+				CompanyID: "mock",
+				UserID:    "mock",
+				DealID:    deal,
+				CreatedAt: now.Add(time.Duration(idx) * time.Minute),
+			}))
+		}
+	})
+
+	t.Run("save invalid event", func(t *testing.T) {
+		assert.Error(t, adapter.InsertEvent(context.Background(), domain.Event{
+			Type:      "mock",
+			CompanyID: "mock",
+			CreatedAt: now,
+		}))
+	})
+
+	t.Run("get events by deal", func(t *testing.T) {
+		events, total, err := adapter.SelectEvents(context.Background(), domain.EventFilter{
+			CompanyID: "mock",
+			DealID:    "1",
+			Page:      1,
+			Limit:     10,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Len(t, events, 2)
+		assert.True(t, events[0].CreatedAt.After(events[1].CreatedAt))
+	})
+
+	t.Run("get events page by time range", func(t *testing.T) {
+		events, total, err := adapter.SelectEvents(context.Background(), domain.EventFilter{
+			CompanyID: "mock",
+			From:      now.Add(30 * time.Second),
+			Page:      2,
+			Limit:     1,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Len(t, events, 1)
+		assert.Equal(t, "1", events[0].DealID)
+	})
+
+	t.Run("delete events by cid", func(t *testing.T) {
+		assert.NoError(t, adapter.DeleteEvents(context.Background(), "mock"))
+		_, total, err := adapter.SelectEvents(context.Background(), domain.EventFilter{
+			CompanyID: "mock",
+			Page:      1,
+			Limit:     10,
+		})
+		assert.NoError(t, err)
+		assert.Zero(t, total)
+	})
+}
@@ -0,0 +1,140 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/core/port"
+	"github.com/kamva/mgm/v3"
+	"github.com/kamva/mgm/v3/operator"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type auditEventCollection struct {
+	mgm.DefaultModel `bson:",inline"`
+	Type             string            `json:"type" bson:"type"`
+	CompanyID        string            `json:"company_id" bson:"company_id"`
+	UserID           string            `json:"user_id" bson:"user_id"`
+	DealID           string            `json:"deal_id" bson:"deal_id"`
+	FileID           string            `json:"file_id" bson:"file_id"`
+	Details          map[string]string `json:"details,omitempty" bson:"details,omitempty"`
+	OccurredAt       time.Time         `json:"occurred_at" bson:"occurred_at"`
+}
+
+type mongoAuditAdapter struct {
+}
+
+func NewMongoAuditAdapter(url string) port.AuditServiceAdapter {
+	if err := mgm.SetDefaultConfig(
+		&mgm.Config{CtxTimeout: 3 * time.Second}, "pipedrive",
+		options.Client().ApplyURI(url),
+	); err != nil {
+		log.Fatalf("mongo initialization error: %s", err.Error())
+	}
+
+	return &mongoAuditAdapter{}
+}
+
+func (m *mongoAuditAdapter) InsertEvent(ctx context.Context, event domain.Event) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	return mgm.Coll(&auditEventCollection{}).CreateWithCtx(ctx, &auditEventCollection{
+		Type:       event.Type,
+		CompanyID:  event.CompanyID,
+		UserID:     event.UserID,
+		DealID:     event.DealID,
+		FileID:     event.FileID,
+		Details:    event.Details,
+		OccurredAt: event.CreatedAt,
+	})
+}
+
+func (m *mongoAuditAdapter) SelectEvents(ctx context.Context, filter domain.EventFilter) ([]domain.Event, int64, error) {
+	query := bson.M{"company_id": filter.CompanyID}
+	if filter.UserID != "" {
+		query["user_id"] = filter.UserID
+	}
+
+	if filter.DealID != "" {
+		query["deal_id"] = filter.DealID
+	}
+
+	period := bson.M{}
+	if !filter.From.IsZero() {
+		period[operator.Gte] = filter.From
+	}
+
+	if !filter.To.IsZero() {
+		period[operator.Lte] = filter.To
+	}
+
+	if len(period) > 0 {
+		query["occurred_at"] = period
+	}
+
+	collection := mgm.Coll(&auditEventCollection{})
+	total, err := collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var results []auditEventCollection
+	if err := collection.SimpleFindWithCtx(ctx, &results, query, options.Find().
+		SetSort(bson.M{"occurred_at": -1}).
+		SetSkip(int64((filter.Page-1)*filter.Limit)).
+		SetLimit(int64(filter.Limit)),
+	); err != nil {
+		return nil, 0, err
+	}
+
+	events := make([]domain.Event, 0, len(results))
+	for _, result := range results {
+		events = append(events, domain.Event{
+			ID:        result.ID.Hex(),
+			Type:      result.Type,
+			CompanyID: result.CompanyID,
+			UserID:    result.UserID,
+			DealID:    result.DealID,
+			FileID:    result.FileID,
+			Details:   result.Details,
+			CreatedAt: result.OccurredAt,
+		})
+	}
+
+	return events, total, nil
+}
+
+func (m *mongoAuditAdapter) DeleteEvents(ctx context.Context, cid string) error {
+	cid = strings.TrimSpace(cid)
+
+	if cid == "" {
+		return ErrInvalidCompanyID
+	}
+
+	_, err := mgm.Coll(&auditEventCollection{}).DeleteMany(ctx, bson.M{"company_id": bson.M{operator.Eq: cid}})
+	return err
+}
@@ -0,0 +1,163 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	EventConfigBuilt         = "config.built"
+	EventCallbackSaved       = "callback.saved"
+	EventSettingsPosted      = "settings.posted"
+	EventAppUninstalled      = "app.uninstalled"
+	EventFileConverted       = "file.converted"
+	EventFileExported        = "file.exported"
+	EventSessionForceSaved   = "session.forcesaved"
+	EventSessionDisconnected = "session.disconnected"
+)
+
+type Event struct {
+	ID        string            `json:"id" mapstructure:"id"`
+	Type      string            `json:"type" mapstructure:"type"`
+	CompanyID string            `json:"company_id" mapstructure:"company_id"`
+	UserID    string            `json:"user_id" mapstructure:"user_id"`
+	DealID    string            `json:"deal_id" mapstructure:"deal_id"`
+	FileID    string            `json:"file_id" mapstructure:"file_id"`
+	Details   map[string]string `json:"details,omitempty" mapstructure:"details"`
+	CreatedAt time.Time         `json:"created_at" mapstructure:"created_at"`
+}
+
+func (e Event) ToJSON() []byte {
+	buf, _ := json.Marshal(e)
+	return buf
+}
+
+func (e *Event) Validate() error {
+	e.Type = strings.TrimSpace(e.Type)
+	e.CompanyID = strings.TrimSpace(e.CompanyID)
+	e.UserID = strings.TrimSpace(e.UserID)
+	e.DealID = strings.TrimSpace(e.DealID)
+	e.FileID = strings.TrimSpace(e.FileID)
+
+	switch e.Type {
+	case EventConfigBuilt, EventCallbackSaved, EventSettingsPosted, EventAppUninstalled,
+		EventFileConverted, EventFileExported, EventSessionForceSaved, EventSessionDisconnected:
+	default:
+		return &InvalidModelFieldError{
+			Model:  "Event",
+			Field:  "Type",
+			Reason: "Unknown event type",
+		}
+	}
+
+	if e.CompanyID == "" {
+		return &InvalidModelFieldError{
+			Model:  "Event",
+			Field:  "CompanyID",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if e.CreatedAt.IsZero() {
+		return &InvalidModelFieldError{
+			Model:  "Event",
+			Field:  "CreatedAt",
+			Reason: "Should not be empty",
+		}
+	}
+
+	return nil
+}
+
+type EventFilter struct {
+	CompanyID string    `json:"company_id" mapstructure:"company_id"`
+	UserID    string    `json:"user_id" mapstructure:"user_id"`
+	DealID    string    `json:"deal_id" mapstructure:"deal_id"`
+	From      time.Time `json:"from" mapstructure:"from"`
+	To        time.Time `json:"to" mapstructure:"to"`
+	Page      int       `json:"page" mapstructure:"page"`
+	Limit     int       `json:"limit" mapstructure:"limit"`
+}
+
+func (f *EventFilter) Validate() error {
+	f.CompanyID = strings.TrimSpace(f.CompanyID)
+	f.UserID = strings.TrimSpace(f.UserID)
+	f.DealID = strings.TrimSpace(f.DealID)
+
+	if f.CompanyID == "" {
+		return &InvalidModelFieldError{
+			Model:  "EventFilter",
+			Field:  "CompanyID",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if !f.From.IsZero() && !f.To.IsZero() && f.To.Before(f.From) {
+		return &InvalidModelFieldError{
+			Model:  "EventFilter",
+			Field:  "To",
+			Reason: "Should not be before From",
+		}
+	}
+
+	if f.Page < 1 {
+		return &InvalidModelFieldError{
+			Model:  "EventFilter",
+			Field:  "Page",
+			Reason: "Invalid page value. Expected page > 0",
+		}
+	}
+
+	if f.Limit < 1 || f.Limit > 100 {
+		return &InvalidModelFieldError{
+			Model:  "EventFilter",
+			Field:  "Limit",
+			Reason: "Invalid limit value. Expected 0 < limit <= 100",
+		}
+	}
+
+	return nil
+}
+
+func (f EventFilter) Matches(e Event) bool {
+	if e.CompanyID != f.CompanyID {
+		return false
+	}
+
+	if f.UserID != "" && e.UserID != f.UserID {
+		return false
+	}
+
+	if f.DealID != "" && e.DealID != f.DealID {
+		return false
+	}
+
+	if !f.From.IsZero() && e.CreatedAt.Before(f.From) {
+		return false
+	}
+
+	if !f.To.IsZero() && e.CreatedAt.After(f.To) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,76 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package service
+
+import (
+	"context"
+	"strings"
+
+	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/core/port"
+)
+
+type auditService struct {
+	adapter port.AuditServiceAdapter
+	logger  plog.Logger
+}
+
+func NewAuditService(
+	adapter port.AuditServiceAdapter,
+	logger plog.Logger,
+) port.AuditService {
+	return auditService{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+func (s auditService) CreateEvent(ctx context.Context, event domain.Event) error {
+	s.logger.Debugf("validating %s event of company %s to perform a persist action", event.Type, event.CompanyID)
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	s.logger.Debugf("event %s is valid. Persisting to database", event.Type)
+	return s.adapter.InsertEvent(ctx, event)
+}
+
+func (s auditService) GetEvents(ctx context.Context, filter domain.EventFilter) ([]domain.Event, int64, error) {
+	s.logger.Debugf("trying to select events of company %s", filter.CompanyID)
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	return s.adapter.SelectEvents(ctx, filter)
+}
+
+func (s auditService) RemoveEvents(ctx context.Context, cid string) error {
+	id := strings.TrimSpace(cid)
+	s.logger.Debugf("validating cid %s to perform a delete action", id)
+
+	if id == "" {
+		return &InvalidServiceParameterError{
+			Name:   "CID",
+			Reason: "Should not be blank",
+		}
+	}
+
+	return s.adapter.DeleteEvents(ctx, id)
+}
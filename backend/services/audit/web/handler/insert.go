@@ -0,0 +1,67 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+)
+
+type AuditInsertHandler struct {
+	service port.AuditService
+	logger  log.Logger
+}
+
+func NewAuditInsertHandler(
+	service port.AuditService,
+	logger log.Logger,
+) AuditInsertHandler {
+	return AuditInsertHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (i AuditInsertHandler) InsertEvent(ctx context.Context, req request.AuditEvent, res *interface{}) error {
+	if err := i.service.CreateEvent(ctx, domain.Event{
+		Type:      req.Type,
+		CompanyID: req.CompanyID,
+		UserID:    req.UserID,
+		DealID:    req.DealID,
+		FileID:    req.FileID,
+		Details:   req.Details,
+		CreatedAt: req.CreatedAt,
+	}); err != nil {
+		i.logger.Errorf("could not persist %s event: %s", req.Type, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// HandleEvent persists audit events published to the broker. A returned error
+// leaves the message to the broker's redelivery.
+func (i AuditInsertHandler) HandleEvent(ctx context.Context, req request.AuditEvent) error {
+	var res interface{}
+	return i.InsertEvent(ctx, req, &res)
+}
@@ -0,0 +1,81 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+)
+
+type AuditSelectHandler struct {
+	service port.AuditService
+	logger  log.Logger
+}
+
+func NewAuditSelectHandler(
+	service port.AuditService,
+	logger log.Logger,
+) AuditSelectHandler {
+	return AuditSelectHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (u AuditSelectHandler) GetEvents(ctx context.Context, req request.AuditQuery, res *response.AuditEventsResponse) error {
+	events, total, err := u.service.GetEvents(ctx, domain.EventFilter{
+		CompanyID: req.CompanyID,
+		UserID:    req.UserID,
+		DealID:    req.DealID,
+		From:      req.From,
+		To:        req.To,
+		Page:      req.Page,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		u.logger.Warnf("could not get company %s events. Reason: %s", req.CompanyID, err.Error())
+		return err
+	}
+
+	*res = response.AuditEventsResponse{
+		Events: make([]response.AuditEventResponse, 0, len(events)),
+		Total:  total,
+		Page:   req.Page,
+		Limit:  req.Limit,
+	}
+
+	for _, event := range events {
+		res.Events = append(res.Events, response.AuditEventResponse{
+			ID:        event.ID,
+			Type:      event.Type,
+			UserID:    event.UserID,
+			DealID:    event.DealID,
+			FileID:    event.FileID,
+			Details:   event.Details,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package web
+
+import (
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/service/rpc"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/audit/web/handler"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+)
+
+type AuditRPCServer struct {
+	selectHandler handler.AuditSelectHandler
+	insertHandler handler.AuditInsertHandler
+	config        *config.ServerConfig
+}
+
+func NewAuditRPCServer(
+	selectHandler handler.AuditSelectHandler,
+	insertHandler handler.AuditInsertHandler,
+	config *config.ServerConfig,
+) rpc.RPCEngine {
+	return AuditRPCServer{
+		selectHandler: selectHandler,
+		insertHandler: insertHandler,
+		config:        config,
+	}
+}
+
+func (a AuditRPCServer) BuildMessageHandlers() []rpc.RPCMessageHandler {
+	return []rpc.RPCMessageHandler{
+		{
+			Topic:   shared.AuditTopic(a.config.Namespace),
+			Handler: a.insertHandler.HandleEvent,
+		},
+	}
+}
+
+func (a AuditRPCServer) BuildHandlers() []interface{} {
+	return []interface{}{a.selectHandler, a.insertHandler}
+}
@@ -0,0 +1,97 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	pkg "github.com/ONLYOFFICE/onlyoffice-integration-adapters"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/service/rpc"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/adapter"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/service"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/handler"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
+	"github.com/urfave/cli/v2"
+)
+
+func Migrate() *cli.Command {
+	return &cli.Command{
+		Name:     "migrate",
+		Usage:    "rewrites legacy user keys to composite user identities",
+		Category: "maintenance",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config_path",
+				Usage:   "sets custom configuration path",
+				Aliases: []string{"config", "conf", "c"},
+			},
+			&cli.BoolFlag{
+				Name:  "dry_run",
+				Usage: "reports legacy users without rewriting them",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "sets the migration timeout",
+				Value: 10 * time.Minute,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			var (
+				CONFIG_PATH = c.String("config_path")
+				DRY_RUN     = c.Bool("dry_run")
+				TIMEOUT     = c.Duration("timeout")
+			)
+
+			app := pkg.NewBootstrapper(CONFIG_PATH, pkg.WithModules(
+				shared.BuildNewIntegrationCredentialsConfig(CONFIG_PATH),
+				shared.BuildNewDistributedCacheConfig(CONFIG_PATH),
+				cache.NewCache,
+				shared.NewEventPublisher,
+				rpc.NewService, web.NewAuthRPCServer,
+				adapter.BuildNewUserAdapter, service.NewUserService,
+				handler.NewUserSelectHandler, handler.NewUserInsertHandler,
+				handler.NewUserDeleteHandler,
+				client.NewPipedriveAuthClient, client.NewPipedriveApiClient,
+				handler.NewIdentityMigrator,
+			), pkg.WithInvokables(func(migrator handler.IdentityMigrator, logger log.Logger) error {
+				ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+				defer cancel()
+
+				report, err := migrator.Migrate(ctx, DRY_RUN)
+				if err != nil {
+					return err
+				}
+
+				logger.Infof(
+					"identity migration finished. Legacy users: %d, migrated: %d, skipped: %d, failed: %d",
+					report.Total, report.Migrated, report.Skipped, report.Failed,
+				)
+
+				return nil
+			})).Bootstrap()
+
+			return app.Err()
+		},
+	}
+}
@@ -26,7 +26,9 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/service"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/handler"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
 	"github.com/urfave/cli/v2"
 )
 
@@ -49,11 +51,18 @@ func Server() *cli.Command {
 
 			app := pkg.NewBootstrapper(CONFIG_PATH, pkg.WithModules(
 				shared.BuildNewIntegrationCredentialsConfig(CONFIG_PATH),
+				shared.BuildNewDistributedCacheConfig(CONFIG_PATH),
+				cache.NewCache,
+				shared.NewEventPublisher,
+				shared.BuildNewRefresherConfig(CONFIG_PATH),
 				rpc.NewService, web.NewAuthRPCServer,
 				adapter.BuildNewUserAdapter, service.NewUserService,
 				handler.NewUserSelectHandler, handler.NewUserInsertHandler,
 				handler.NewUserDeleteHandler,
-				client.NewPipedriveAuthClient,
+				client.NewPipedriveAuthClient, handler.NewTokenRefresher,
+			), pkg.WithInvokables(
+				handler.RunTokenRefresher,
+				cache.BuildRunInvalidator(request.EventUserUpdated, request.EventUserDeleted),
 			)).Bootstrap()
 
 			if err := app.Err(); err != nil {
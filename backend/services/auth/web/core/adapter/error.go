@@ -23,4 +23,5 @@ import "errors"
 var (
 	ErrInvalidUserId     error = errors.New("invalid uid format")
 	ErrUserAlreadyExists error = errors.New("user already exists")
+	ErrInvalidCompanyId  error = errors.New("invalid cid format")
 )
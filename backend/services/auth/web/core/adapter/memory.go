@@ -22,12 +22,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sync"
 
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/domain"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/port"
 )
 
 type memoryUserAdapter struct {
+	mu  sync.RWMutex
 	kvs map[string][]byte
 }
 
@@ -44,17 +46,40 @@ func (m *memoryUserAdapter) save(user domain.UserAccess) error {
 		return err
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.kvs[user.ID] = buffer
 
 	return nil
 }
 
+func (m *memoryUserAdapter) filter(matches func(domain.UserAccess) bool) ([]domain.UserAccess, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	users := make([]domain.UserAccess, 0)
+	for _, buffer := range m.kvs {
+		var user domain.UserAccess
+		if err := json.Unmarshal(buffer, &user); err != nil {
+			return nil, err
+		}
+
+		if matches(user) {
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
 func (m *memoryUserAdapter) InsertUser(ctx context.Context, user domain.UserAccess) error {
 	return m.save(user)
 }
 
 func (m *memoryUserAdapter) SelectUser(ctx context.Context, uid string) (domain.UserAccess, error) {
+	m.mu.RLock()
 	buffer, ok := m.kvs[uid]
+	m.mu.RUnlock()
 	var user domain.UserAccess
 
 	if !ok {
@@ -77,6 +102,9 @@ func (m *memoryUserAdapter) UpsertUser(ctx context.Context, user domain.UserAcce
 }
 
 func (m *memoryUserAdapter) DeleteUser(ctx context.Context, uid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if _, ok := m.kvs[uid]; !ok {
 		return errors.New("user with this id doesn't exist")
 	}
@@ -85,3 +113,36 @@ func (m *memoryUserAdapter) DeleteUser(ctx context.Context, uid string) error {
 
 	return nil
 }
+
+func (m *memoryUserAdapter) SelectUsers(ctx context.Context) ([]domain.UserAccess, error) {
+	return m.filter(func(user domain.UserAccess) bool {
+		return true
+	})
+}
+
+func (m *memoryUserAdapter) SelectExpiringUsers(ctx context.Context, before int64) ([]domain.UserAccess, error) {
+	return m.filter(func(user domain.UserAccess) bool {
+		return !user.Revoked && user.ExpiresAt <= before
+	})
+}
+
+func (m *memoryUserAdapter) SelectCompanyUsers(ctx context.Context, cid string) ([]domain.UserAccess, error) {
+	return m.filter(func(user domain.UserAccess) bool {
+		return user.CompanyID == cid
+	})
+}
+
+func (m *memoryUserAdapter) DeleteCompanyUsers(ctx context.Context, cid string) error {
+	users, err := m.SelectCompanyUsers(ctx, cid)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, user := range users {
+		delete(m.kvs, user.ID)
+	}
+
+	return nil
+}
@@ -60,4 +60,45 @@ func TestMemoryAdapter(t *testing.T) {
 		_, err := adapter.SelectUser(context.Background(), "mock")
 		assert.Error(t, err)
 	})
+
+	t.Run("select expiring users", func(t *testing.T) {
+		assert.NoError(t, adapter.InsertUser(context.Background(), domain.UserAccess{
+			ID:        "expiring",
+			ExpiresAt: 100,
+		}))
+		assert.NoError(t, adapter.InsertUser(context.Background(), domain.UserAccess{
+			ID:        "revoked",
+			ExpiresAt: 100,
+			Revoked:   true,
+		}))
+
+		users, err := adapter.SelectExpiringUsers(context.Background(), 200)
+		assert.NoError(t, err)
+		assert.Len(t, users, 1)
+		assert.Equal(t, "expiring", users[0].ID)
+
+		users, err = adapter.SelectExpiringUsers(context.Background(), 50)
+		assert.NoError(t, err)
+		assert.Empty(t, users)
+
+		assert.NoError(t, adapter.DeleteUser(context.Background(), "expiring"))
+		assert.NoError(t, adapter.DeleteUser(context.Background(), "revoked"))
+	})
+
+	t.Run("delete company users", func(t *testing.T) {
+		assert.NoError(t, adapter.InsertUser(context.Background(), domain.UserAccess{
+			ID:          "mock",
+			CompanyID:   "company",
+			AccessToken: "mock",
+		}))
+
+		users, err := adapter.SelectCompanyUsers(context.Background(), "company")
+		assert.NoError(t, err)
+		assert.Len(t, users, 1)
+
+		assert.NoError(t, adapter.DeleteCompanyUsers(context.Background(), "company"))
+		users, err = adapter.SelectCompanyUsers(context.Background(), "company")
+		assert.NoError(t, err)
+		assert.Empty(t, users)
+	})
 }
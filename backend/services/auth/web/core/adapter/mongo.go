@@ -36,12 +36,14 @@ import (
 type userAccessCollection struct {
 	mgm.DefaultModel `bson:",inline"`
 	UID              string `json:"uid" bson:"uid"`
+	CompanyID        string `json:"company_id" bson:"company_id"`
 	AccessToken      string `json:"access_token"`
 	RefreshToken     string `json:"refresh_token"`
 	TokenType        string `json:"token_type"`
 	Scope            string `json:"scope"`
 	ExpiresAt        int64  `json:"expires_at"`
 	ApiDomain        string `json:"api_domain"`
+	Revoked          bool   `json:"revoked"`
 }
 
 type mongoUserAdapter struct {
@@ -66,12 +68,14 @@ func (m *mongoUserAdapter) save(ctx context.Context, user domain.UserAccess) err
 		if err := collection.FirstWithCtx(ctx, bson.M{"uid": user.ID}, u); err != nil {
 			if cerr := collection.CreateWithCtx(ctx, &userAccessCollection{
 				UID:          user.ID,
+				CompanyID:    user.CompanyID,
 				AccessToken:  user.AccessToken,
 				RefreshToken: user.RefreshToken,
 				TokenType:    user.TokenType,
 				Scope:        user.Scope,
 				ExpiresAt:    user.ExpiresAt,
 				ApiDomain:    user.ApiDomain,
+				Revoked:      user.Revoked,
 			}); cerr != nil {
 				return cerr
 			}
@@ -79,6 +83,10 @@ func (m *mongoUserAdapter) save(ctx context.Context, user domain.UserAccess) err
 			return session.CommitTransaction(sc)
 		}
 
+		if user.CompanyID != "" {
+			u.CompanyID = user.CompanyID
+		}
+
 		u.AccessToken = user.AccessToken
 		u.RefreshToken = user.RefreshToken
 		u.TokenType = user.TokenType
@@ -86,6 +94,7 @@ func (m *mongoUserAdapter) save(ctx context.Context, user domain.UserAccess) err
 		u.ExpiresAt = user.ExpiresAt
 		u.UpdatedAt = time.Now()
 		u.ApiDomain = user.ApiDomain
+		u.Revoked = user.Revoked
 
 		if err := collection.UpdateWithCtx(ctx, u); err != nil {
 			return err
@@ -114,12 +123,14 @@ func (m *mongoUserAdapter) SelectUser(ctx context.Context, uid string) (domain.U
 	collection := mgm.Coll(user)
 	return domain.UserAccess{
 		ID:           user.UID,
+		CompanyID:    user.CompanyID,
 		AccessToken:  user.AccessToken,
 		RefreshToken: user.RefreshToken,
 		TokenType:    user.TokenType,
 		Scope:        user.Scope,
 		ExpiresAt:    user.ExpiresAt,
 		ApiDomain:    user.ApiDomain,
+		Revoked:      user.Revoked,
 	}, collection.FirstWithCtx(ctx, bson.M{"uid": uid}, user)
 }
 
@@ -141,3 +152,64 @@ func (m *mongoUserAdapter) DeleteUser(ctx context.Context, uid string) error {
 	_, err := mgm.Coll(&userAccessCollection{}).DeleteMany(ctx, bson.M{"uid": bson.M{operator.Eq: uid}})
 	return err
 }
+
+func (m *mongoUserAdapter) find(ctx context.Context, filter bson.M) ([]domain.UserAccess, error) {
+	var results []userAccessCollection
+	if err := mgm.Coll(&userAccessCollection{}).SimpleFindWithCtx(ctx, &results, filter); err != nil {
+		return nil, err
+	}
+
+	users := make([]domain.UserAccess, 0, len(results))
+	for _, user := range results {
+		users = append(users, domain.UserAccess{
+			ID:           user.UID,
+			CompanyID:    user.CompanyID,
+			AccessToken:  user.AccessToken,
+			RefreshToken: user.RefreshToken,
+			TokenType:    user.TokenType,
+			Scope:        user.Scope,
+			ExpiresAt:    user.ExpiresAt,
+			ApiDomain:    user.ApiDomain,
+			Revoked:      user.Revoked,
+		})
+	}
+
+	return users, nil
+}
+
+func (m *mongoUserAdapter) SelectUsers(ctx context.Context) ([]domain.UserAccess, error) {
+	return m.find(ctx, bson.M{})
+}
+
+func (m *mongoUserAdapter) SelectExpiringUsers(ctx context.Context, before int64) ([]domain.UserAccess, error) {
+	return m.find(ctx, bson.M{
+		"expiresat": bson.M{operator.Lte: before},
+		"revoked":   bson.M{operator.Ne: true},
+	})
+}
+
+func companyFilter(cid string) bson.M {
+	if cid == "" {
+		return bson.M{operator.Or: []bson.M{
+			{"company_id": bson.M{operator.Exists: false}},
+			{"company_id": ""},
+		}}
+	}
+
+	return bson.M{"company_id": bson.M{operator.Eq: cid}}
+}
+
+func (m *mongoUserAdapter) SelectCompanyUsers(ctx context.Context, cid string) ([]domain.UserAccess, error) {
+	return m.find(ctx, companyFilter(strings.TrimSpace(cid)))
+}
+
+func (m *mongoUserAdapter) DeleteCompanyUsers(ctx context.Context, cid string) error {
+	cid = strings.TrimSpace(cid)
+
+	if cid == "" {
+		return ErrInvalidCompanyId
+	}
+
+	_, err := mgm.Coll(&userAccessCollection{}).DeleteMany(ctx, companyFilter(cid))
+	return err
+}
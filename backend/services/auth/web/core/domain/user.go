@@ -25,12 +25,14 @@ import (
 
 type UserAccess struct {
 	ID           string `json:"id" mapstructure:"id"`
+	CompanyID    string `json:"company_id" mapstructure:"company_id"`
 	AccessToken  string `json:"access_token" mapstructure:"access_token"`
 	RefreshToken string `json:"refresh_token" mapstructure:"refresh_token"`
 	TokenType    string `json:"token_type" mapstructure:"token_type"`
 	Scope        string `json:"scope" mapstructure:"scope"`
 	ExpiresAt    int64  `json:"expires_at" mapstructure:"expires_at"`
 	ApiDomain    string `json:"api_domain" mapstructure:"api_domain"`
+	Revoked      bool   `json:"revoked" mapstructure:"revoked"`
 }
 
 func (u UserAccess) ToJSON() []byte {
@@ -40,6 +42,7 @@ func (u UserAccess) ToJSON() []byte {
 
 func (u *UserAccess) Validate() error {
 	u.ID = strings.TrimSpace(u.ID)
+	u.CompanyID = strings.TrimSpace(u.CompanyID)
 	u.AccessToken = strings.TrimSpace(u.AccessToken)
 	u.RefreshToken = strings.TrimSpace(u.RefreshToken)
 	u.TokenType = strings.TrimSpace(u.TokenType)
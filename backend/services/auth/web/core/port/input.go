@@ -20,6 +20,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/domain"
 )
@@ -29,4 +30,9 @@ type UserAccessService interface {
 	GetUser(ctx context.Context, uid string) (domain.UserAccess, error)
 	UpdateUser(ctx context.Context, user domain.UserAccess) (domain.UserAccess, error)
 	RemoveUser(ctx context.Context, uid string) error
+	GetUsers(ctx context.Context) ([]domain.UserAccess, error)
+	GetExpiringUsers(ctx context.Context, within time.Duration) ([]domain.UserAccess, error)
+	RevokeUser(ctx context.Context, uid string) error
+	GetCompanyUsers(ctx context.Context, cid string) ([]string, error)
+	RemoveCompanyUsers(ctx context.Context, cid string) ([]string, error)
 }
@@ -29,4 +29,8 @@ type UserAccessServiceAdapter interface {
 	SelectUser(ctx context.Context, uid string) (domain.UserAccess, error)
 	UpsertUser(ctx context.Context, user domain.UserAccess) (domain.UserAccess, error)
 	DeleteUser(ctx context.Context, uid string) error
+	SelectUsers(ctx context.Context) ([]domain.UserAccess, error)
+	SelectExpiringUsers(ctx context.Context, before int64) ([]domain.UserAccess, error)
+	SelectCompanyUsers(ctx context.Context, cid string) ([]domain.UserAccess, error)
+	DeleteCompanyUsers(ctx context.Context, cid string) error
 }
@@ -23,7 +23,10 @@ import (
 	"fmt"
 )
 
-var ErrOperationTimeout = errors.New("operation timeout")
+var (
+	ErrOperationTimeout = errors.New("operation timeout")
+	ErrUserTokenRevoked = errors.New("user token has been revoked. The app should be reinstalled")
+)
 
 type InvalidServiceParameterError struct {
 	Name   string
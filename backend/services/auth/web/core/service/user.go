@@ -27,8 +27,10 @@ import (
 	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/domain"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
 	"github.com/mitchellh/mapstructure"
-	"go-micro.dev/v4/cache"
 	"golang.org/x/oauth2"
 )
 
@@ -36,6 +38,7 @@ type userService struct {
 	adapter     port.UserAccessServiceAdapter
 	encryptor   crypto.Encryptor
 	cache       cache.Cache
+	events      shared.EventPublisher
 	credentials *oauth2.Config
 	logger      plog.Logger
 }
@@ -44,6 +47,7 @@ func NewUserService(
 	adapter port.UserAccessServiceAdapter,
 	encryptor crypto.Encryptor,
 	cache cache.Cache,
+	events shared.EventPublisher,
 	credentials *oauth2.Config,
 	logger plog.Logger,
 ) port.UserAccessService {
@@ -51,6 +55,7 @@ func NewUserService(
 		adapter:     adapter,
 		encryptor:   encryptor,
 		cache:       cache,
+		events:      events,
 		credentials: credentials,
 		logger:      logger,
 	}
@@ -75,12 +80,14 @@ func (s userService) CreateUser(ctx context.Context, user domain.UserAccess) err
 	s.logger.Debugf("user %s is valid. Persisting to database: %s", user.ID, user.AccessToken)
 	if err := s.adapter.InsertUser(ctx, domain.UserAccess{
 		ID:           user.ID,
+		CompanyID:    user.CompanyID,
 		AccessToken:  aToken,
 		RefreshToken: rToken,
 		TokenType:    user.TokenType,
 		Scope:        user.Scope,
 		ExpiresAt:    user.ExpiresAt,
 		ApiDomain:    user.ApiDomain,
+		Revoked:      user.Revoked,
 	}); err != nil {
 		return err
 	}
@@ -119,6 +126,10 @@ func (s userService) GetUser(ctx context.Context, uid string) (domain.UserAccess
 
 	s.logger.Debugf("found a user: %v", user)
 
+	if user.Revoked {
+		return domain.UserAccess{}, ErrUserTokenRevoked
+	}
+
 	aToken, err := s.encryptor.Decrypt(user.AccessToken, []byte(s.credentials.ClientSecret))
 	if err != nil {
 		return domain.UserAccess{}, err
@@ -131,6 +142,7 @@ func (s userService) GetUser(ctx context.Context, uid string) (domain.UserAccess
 
 	return domain.UserAccess{
 		ID:           user.ID,
+		CompanyID:    user.CompanyID,
 		AccessToken:  aToken,
 		RefreshToken: rToken,
 		TokenType:    user.TokenType,
@@ -158,6 +170,7 @@ func (s userService) UpdateUser(ctx context.Context, user domain.UserAccess) (do
 
 	euser := domain.UserAccess{
 		ID:           user.ID,
+		CompanyID:    user.CompanyID,
 		AccessToken:  aToken,
 		RefreshToken: rToken,
 		TokenType:    user.TokenType,
@@ -166,19 +179,138 @@ func (s userService) UpdateUser(ctx context.Context, user domain.UserAccess) (do
 		ApiDomain:    user.ApiDomain,
 	}
 
-	if err := s.cache.Put(ctx, euser.ID, euser, time.Duration((euser.ExpiresAt-time.Now().UnixMilli())*1e6/6)); err != nil {
-		s.logger.Warnf("could not populate cache with a user %s instance: %s", euser.ID, err.Error())
-		s.cache.Delete(ctx, euser.ID)
-	}
-
 	s.logger.Debugf("user %s is valid to perform an update action", user.ID)
 	if _, err := s.adapter.UpsertUser(ctx, euser); err != nil {
 		return user, err
 	}
 
+	// Other replicas must not keep serving the previous tokens.
+	s.invalidate(ctx, request.EventUserUpdated, euser.ID)
 	return user, nil
 }
 
+func (s userService) decryptUsers(users []domain.UserAccess) []domain.UserAccess {
+	result := make([]domain.UserAccess, 0, len(users))
+	for _, user := range users {
+		aToken, err := s.encryptor.Decrypt(user.AccessToken, []byte(s.credentials.ClientSecret))
+		if err != nil {
+			s.logger.Warnf("could not decrypt user %s access token: %s", user.ID, err.Error())
+			continue
+		}
+
+		rToken, err := s.encryptor.Decrypt(user.RefreshToken, []byte(s.credentials.ClientSecret))
+		if err != nil {
+			s.logger.Warnf("could not decrypt user %s refresh token: %s", user.ID, err.Error())
+			continue
+		}
+
+		user.AccessToken = aToken
+		user.RefreshToken = rToken
+		result = append(result, user)
+	}
+
+	return result
+}
+
+func (s userService) GetUsers(ctx context.Context) ([]domain.UserAccess, error) {
+	s.logger.Debug("trying to select all users")
+	users, err := s.adapter.SelectUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decryptUsers(users), nil
+}
+
+func (s userService) GetExpiringUsers(ctx context.Context, within time.Duration) ([]domain.UserAccess, error) {
+	s.logger.Debugf("trying to select users with tokens expiring within %s", within.String())
+	users, err := s.adapter.SelectExpiringUsers(ctx, time.Now().Add(within).UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decryptUsers(users), nil
+}
+
+func (s userService) RevokeUser(ctx context.Context, uid string) error {
+	id := strings.TrimSpace(uid)
+	s.logger.Debugf("validating uid %s to perform a revoke action", id)
+
+	if id == "" {
+		return &InvalidServiceParameterError{
+			Name:   "UID",
+			Reason: "Should not be blank",
+		}
+	}
+
+	user, err := s.adapter.SelectUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	user.Revoked = true
+	if _, err := s.adapter.UpsertUser(ctx, user); err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, request.EventUserUpdated, id)
+
+	s.logger.Debugf("user %s has been marked as revoked", id)
+	return nil
+}
+
+func (s userService) GetCompanyUsers(ctx context.Context, cid string) ([]string, error) {
+	id := strings.TrimSpace(cid)
+	s.logger.Debugf("trying to select users of company %s", id)
+
+	users, err := s.adapter.SelectCompanyUsers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		if user.Revoked {
+			continue
+		}
+
+		ids = append(ids, user.ID)
+	}
+
+	return ids, nil
+}
+
+func (s userService) RemoveCompanyUsers(ctx context.Context, cid string) ([]string, error) {
+	id := strings.TrimSpace(cid)
+	s.logger.Debugf("validating cid %s to perform a company delete action", id)
+
+	if id == "" {
+		return nil, &InvalidServiceParameterError{
+			Name:   "CID",
+			Reason: "Should not be blank",
+		}
+	}
+
+	users, err := s.adapter.SelectCompanyUsers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+
+	s.logger.Debugf("removing %d users of company %s", len(ids), id)
+	if err := s.adapter.DeleteCompanyUsers(ctx, id); err != nil {
+		return nil, err
+	}
+
+	s.invalidate(ctx, request.EventUserDeleted, ids...)
+
+	return ids, nil
+}
+
 func (s userService) RemoveUser(ctx context.Context, uid string) error {
 	id := strings.TrimSpace(uid)
 	s.logger.Debugf("validating uid %s to perform a delete action", id)
@@ -190,10 +322,20 @@ func (s userService) RemoveUser(ctx context.Context, uid string) error {
 		}
 	}
 
-	if err := s.cache.Delete(ctx, uid); err != nil {
+	s.logger.Debugf("uid %s is valid to perform a delete action", id)
+	if err := s.adapter.DeleteUser(ctx, uid); err != nil {
 		return err
 	}
 
-	s.logger.Debugf("uid %s is valid to perform a delete action", id)
-	return s.adapter.DeleteUser(ctx, uid)
+	s.events.Publish(request.EventUserDeleted, uid)
+	return s.cache.Invalidate(ctx, uid)
+}
+
+// invalidate drops cached users here and tells other instances to drop theirs.
+func (s userService) invalidate(ctx context.Context, event string, ids ...string) {
+	if err := s.cache.Invalidate(ctx, ids...); err != nil {
+		s.logger.Warnf("could not invalidate cached users %v: %s", ids, err.Error())
+	}
+
+	s.events.Publish(event, ids...)
 }
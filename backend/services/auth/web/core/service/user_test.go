@@ -23,11 +23,15 @@ import (
 	"testing"
 	"time"
 
-	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/cache"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/adapter"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
 	"github.com/stretchr/testify/assert"
+	"go-micro.dev/v4/broker"
 	"golang.org/x/oauth2"
 )
 
@@ -73,8 +77,30 @@ func (m mockAdapter) DeleteUser(ctx context.Context, uid string) error {
 	return nil
 }
 
+func (m mockAdapter) SelectUsers(ctx context.Context) ([]domain.UserAccess, error) {
+	return []domain.UserAccess{user}, nil
+}
+
+func (m mockAdapter) SelectExpiringUsers(ctx context.Context, before int64) ([]domain.UserAccess, error) {
+	return []domain.UserAccess{user}, nil
+}
+
+func (m mockAdapter) SelectCompanyUsers(ctx context.Context, cid string) ([]domain.UserAccess, error) {
+	return []domain.UserAccess{user}, nil
+}
+
+func (m mockAdapter) DeleteCompanyUsers(ctx context.Context, cid string) error {
+	return nil
+}
+
+func newEventPublisher() shared.EventPublisher {
+	return shared.NewEventPublisher(messaging.BrokerWithOptions{
+		Broker: broker.NewMemoryBroker(),
+	}, &config.ServerConfig{}, log.NewEmptyLogger())
+}
+
 func TestUserService(t *testing.T) {
-	service := NewUserService(mockAdapter{}, mockEncryptor{}, cache.NewCache(&config.CacheConfig{}), &oauth2.Config{
+	service := NewUserService(mockAdapter{}, mockEncryptor{}, cache.NewMemoryCache(), newEventPublisher(), &oauth2.Config{
 		ClientID:     "mock",
 		ClientSecret: "mock",
 	}, log.NewEmptyLogger())
@@ -128,3 +154,31 @@ func TestUserService(t *testing.T) {
 		assert.NoError(t, service.RemoveUser(context.Background(), "mock"))
 	})
 }
+
+func TestCompanyUsers(t *testing.T) {
+	service := NewUserService(adapter.NewMemoryUserAdapter(), mockEncryptor{}, cache.NewMemoryCache(), newEventPublisher(), &oauth2.Config{
+		ClientID:     "mock",
+		ClientSecret: "mock",
+	}, log.NewEmptyLogger())
+
+	for _, usr := range []domain.UserAccess{
+		{ID: "1:1", CompanyID: "1", AccessToken: "mock", RefreshToken: "mock", TokenType: "mock", Scope: "mock", ExpiresAt: 1000000, ApiDomain: "pipedrive"},
+		{ID: "1:2", CompanyID: "1", AccessToken: "mock", RefreshToken: "mock", TokenType: "mock", Scope: "mock", ExpiresAt: 1000000, ApiDomain: "pipedrive"},
+	} {
+		assert.NoError(t, service.CreateUser(context.Background(), usr))
+	}
+
+	assert.NoError(t, service.RevokeUser(context.Background(), "1:2"))
+
+	t.Run("skip revoked users", func(t *testing.T) {
+		ids, err := service.GetCompanyUsers(context.Background(), "1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1:1"}, ids)
+	})
+
+	t.Run("remove revoked users with the company", func(t *testing.T) {
+		ids, err := service.RemoveCompanyUsers(context.Background(), "1")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"1:1", "1:2"}, ids)
+	})
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
 	"go-micro.dev/v4/client"
 )
 
@@ -58,3 +59,26 @@ func (u UserDeleteHandler) DeleteUser(ctx context.Context, uid *string, res *int
 
 	return err
 }
+
+func (u UserDeleteHandler) DeleteCompanyUsers(ctx context.Context, cid *string, res *response.CompanyUsersResponse) error {
+	ids, err, _ := group.Do(fmt.Sprintf("remove-company-%s", *cid), func() (interface{}, error) {
+		u.logger.Debugf("removing users of company %s", *cid)
+		ids, err := u.service.RemoveCompanyUsers(ctx, *cid)
+		if err != nil {
+			u.logger.Debugf("could not delete company %s users: %s", *cid, err.Error())
+			return nil, err
+		}
+
+		return ids, nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if removed, ok := ids.([]string); ok {
+		*res = response.CompanyUsersResponse{IDs: removed}
+	}
+
+	return nil
+}
@@ -44,6 +44,7 @@ func (i UserInsertHandler) InsertUser(ctx context.Context, req response.UserResp
 	_, err, _ := group.Do(fmt.Sprintf("insert-%s", req.ID), func() (interface{}, error) {
 		usr, err := i.service.UpdateUser(ctx, domain.UserAccess{
 			ID:           req.ID,
+			CompanyID:    req.CompanyID,
 			AccessToken:  req.AccessToken,
 			RefreshToken: req.RefreshToken,
 			TokenType:    req.TokenType,
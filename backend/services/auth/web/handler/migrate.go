@@ -0,0 +1,174 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/port"
+	pservice "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/service"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
+)
+
+var ErrIdentityMismatch = errors.New("pipedrive user does not match the legacy user id")
+
+type MigrationReport struct {
+	Total    int
+	Migrated int
+	Skipped  int
+	Failed   int
+}
+
+type IdentityMigrator struct {
+	service       port.UserAccessService
+	pipedriveAuth pclient.PipedriveAuthClient
+	pipedriveAPI  pclient.PipedriveApiClient
+	logger        log.Logger
+}
+
+func NewIdentityMigrator(
+	service port.UserAccessService,
+	pipedriveAuth pclient.PipedriveAuthClient,
+	pipedriveAPI pclient.PipedriveApiClient,
+	logger log.Logger,
+) IdentityMigrator {
+	return IdentityMigrator{
+		service:       service,
+		pipedriveAuth: pipedriveAuth,
+		pipedriveAPI:  pipedriveAPI,
+		logger:        logger,
+	}
+}
+
+// Migrate rewrites users stored with a legacy uid+cid key to their composite identity.
+func (m IdentityMigrator) Migrate(ctx context.Context, dryRun bool) (MigrationReport, error) {
+	var report MigrationReport
+	users, err := m.service.GetUsers(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	for _, user := range users {
+		if _, err := shared.ParseUserIdentity(user.ID); err == nil {
+			continue
+		}
+
+		report.Total++
+		identity, err := m.resolve(ctx, user)
+		if err != nil {
+			m.logger.Warnf("could not resolve legacy user %s identity: %s", user.ID, err.Error())
+			if unresolvable(err) {
+				report.Skipped++
+			} else {
+				report.Failed++
+			}
+			continue
+		}
+
+		if dryRun {
+			m.logger.Infof("legacy user %s would be migrated to %s", user.ID, identity.String())
+			report.Migrated++
+			continue
+		}
+
+		if err := m.rename(ctx, user, identity); err != nil {
+			m.logger.Errorf("could not migrate legacy user %s: %s", user.ID, err.Error())
+			report.Failed++
+			continue
+		}
+
+		m.logger.Debugf("legacy user %s has been migrated to %s", user.ID, identity.String())
+		report.Migrated++
+	}
+
+	return report, nil
+}
+
+// unresolvable tells legacy users that can never be attributed to a company
+// from the ones worth another try.
+func unresolvable(err error) bool {
+	return errors.Is(err, shared.ErrInvalidUserIdentity) ||
+		errors.Is(err, pservice.ErrUserTokenRevoked) ||
+		errors.Is(err, ErrIdentityMismatch)
+}
+
+func (m IdentityMigrator) resolve(ctx context.Context, user domain.UserAccess) (shared.UserIdentity, error) {
+	legacy, err := strconv.Atoi(user.ID)
+	if err != nil {
+		return shared.UserIdentity{}, shared.ErrInvalidUserIdentity
+	}
+
+	if user.CompanyID != "" {
+		cid, err := strconv.Atoi(user.CompanyID)
+		if err != nil || legacy-cid <= 0 {
+			return shared.UserIdentity{}, shared.ErrInvalidUserIdentity
+		}
+
+		return shared.NewUserIdentity(legacy-cid, cid), nil
+	}
+
+	if user.Revoked {
+		return shared.UserIdentity{}, pservice.ErrUserTokenRevoked
+	}
+
+	if user.ExpiresAt <= time.Now().UnixMilli() {
+		refreshed, err := refreshUser(ctx, m.service, m.pipedriveAuth, m.logger, user)
+		if err != nil {
+			return shared.UserIdentity{}, err
+		}
+
+		user = refreshed
+	}
+
+	usr, err := m.pipedriveAPI.GetMe(ctx, model.Token{
+		AccessToken:  user.AccessToken,
+		RefreshToken: user.RefreshToken,
+		TokenType:    user.TokenType,
+		Scope:        user.Scope,
+		ApiDomain:    user.ApiDomain,
+	})
+	if err != nil {
+		return shared.UserIdentity{}, err
+	}
+
+	if shared.LegacyUserID(usr.ID, usr.CompanyID) != user.ID {
+		return shared.UserIdentity{}, ErrIdentityMismatch
+	}
+
+	return shared.NewUserIdentity(usr.ID, usr.CompanyID), nil
+}
+
+func (m IdentityMigrator) rename(ctx context.Context, user domain.UserAccess, identity shared.UserIdentity) error {
+	legacy := user.ID
+	user.ID = identity.String()
+	user.CompanyID = fmt.Sprint(identity.CompanyID)
+	if err := m.service.CreateUser(ctx, user); err != nil {
+		return err
+	}
+
+	return m.service.RemoveUser(ctx, legacy)
+}
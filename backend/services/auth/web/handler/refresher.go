@@ -0,0 +1,154 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
+	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
+	"go.uber.org/fx"
+)
+
+const refresherLease = "token-refresher-lease"
+
+type TokenRefresher struct {
+	service       port.UserAccessService
+	pipedriveAuth pclient.PipedriveAuthClient
+	cache         cache.Cache
+	config        *shared.RefresherConfig
+	logger        log.Logger
+}
+
+func NewTokenRefresher(
+	service port.UserAccessService,
+	pipedriveAuth pclient.PipedriveAuthClient,
+	cache cache.Cache,
+	config *shared.RefresherConfig,
+	logger log.Logger,
+) TokenRefresher {
+	return TokenRefresher{
+		service:       service,
+		pipedriveAuth: pipedriveAuth,
+		cache:         cache,
+		config:        config,
+		logger:        logger,
+	}
+}
+
+// RunTokenRefresher starts refreshing tokens in the background for the app's lifetime.
+func RunTokenRefresher(lifecycle fx.Lifecycle, refresher TokenRefresher) {
+	if !refresher.config.Refresher.Enabled {
+		refresher.logger.Debug("token refresher is disabled")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				refresher.Run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+func (r TokenRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(r.config.Refresher.Interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if r.lease(ctx) {
+			r.RefreshExpiring(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// lease lets a single auth replica refresh tokens per interval. Refresh tokens
+// are rotated on use, so replicas refreshing the same user would get each
+// other's tokens rejected. A replica that can not reach the cache sits the
+// round out.
+func (r TokenRefresher) lease(ctx context.Context) bool {
+	ok, err := r.cache.Acquire(ctx, refresherLease, time.Duration(r.config.Refresher.Interval)*time.Second)
+	if err != nil {
+		r.logger.Errorf("could not acquire the token refresher lease. Reason: %s", err.Error())
+		return false
+	}
+
+	if !ok {
+		r.logger.Debug("token refresher lease is held by another replica")
+	}
+
+	return ok
+}
+
+// RefreshExpiring refreshes every token that expires within the configured window.
+func (r TokenRefresher) RefreshExpiring(ctx context.Context) {
+	tctx, cancel := context.WithTimeout(ctx, time.Duration(r.config.Refresher.Interval)*time.Second)
+	defer cancel()
+
+	users, err := r.service.GetExpiringUsers(tctx, time.Duration(r.config.Refresher.Window)*time.Second)
+	if err != nil {
+		r.logger.Errorf("could not select users with expiring tokens. Reason: %s", err.Error())
+		return
+	}
+
+	if len(users) > 0 {
+		r.logger.Debugf("refreshing %d expiring user tokens", len(users))
+	}
+
+	for _, user := range users {
+		if tctx.Err() != nil {
+			return
+		}
+
+		usr := user
+		if _, err, _ := group.Do(usr.ID, func() (interface{}, error) {
+			access, err := refreshUser(tctx, r.service, r.pipedriveAuth, r.logger, usr)
+			if err != nil {
+				return nil, err
+			}
+
+			return access, nil
+		}); err != nil {
+			r.logger.Warnf("background refresh of user %s token has failed. Reason: %s", usr.ID, err.Error())
+		}
+	}
+}
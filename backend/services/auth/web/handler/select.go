@@ -20,12 +20,15 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/domain"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/port"
+	pservice "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/service"
 	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
 	"go-micro.dev/v4/client"
 )
 
@@ -60,30 +63,11 @@ func (u UserSelectHandler) GetUser(ctx context.Context, uid *string, res *domain
 
 		if user.ExpiresAt <= time.Now().Add(-30*time.Second).UnixMilli() {
 			u.logger.Debug("user token has expired. Trying to refresh!")
-			token, terr := u.pipedriveAuth.RefreshAccessToken(ctx, user.RefreshToken)
-			if terr != nil {
-				u.logger.Errorf("could not refresh user's %s token. Reason: %s", *uid, terr.Error())
-				return nil, terr
-			}
-
-			u.logger.Debugf("user's %s token has been refreshed", *uid)
-			access := domain.UserAccess{
-				ID:           user.ID,
-				AccessToken:  token.AccessToken,
-				RefreshToken: token.RefreshToken,
-				TokenType:    token.TokenType,
-				Scope:        token.Scope,
-				ApiDomain:    token.ApiDomain,
-				ExpiresAt:    time.Now().Local().Add(time.Second * time.Duration(token.ExpiresIn-700)).UnixMilli(),
-			}
-
-			_, err := u.service.UpdateUser(ctx, access)
+			access, err := refreshUser(ctx, u.service, u.pipedriveAuth, u.logger, user)
 			if err != nil {
-				u.logger.Debugf("could not persist a new user's %s token. Reason: %s. Sending a fallback message!", *uid, err.Error())
 				return nil, err
 			}
 
-			u.logger.Debugf("user's %s token has been updated", *uid)
 			return access, nil
 		}
 
@@ -97,3 +81,56 @@ func (u UserSelectHandler) GetUser(ctx context.Context, uid *string, res *domain
 
 	return err
 }
+
+func refreshUser(
+	ctx context.Context,
+	service port.UserAccessService,
+	pipedriveAuth pclient.PipedriveAuthClient,
+	logger log.Logger,
+	user domain.UserAccess,
+) (domain.UserAccess, error) {
+	token, err := pipedriveAuth.RefreshAccessToken(ctx, user.RefreshToken)
+	if err != nil {
+		logger.Errorf("could not refresh user's %s token. Reason: %s", user.ID, err.Error())
+		if errors.Is(err, pclient.ErrRefreshTokenRevoked) {
+			if rerr := service.RevokeUser(ctx, user.ID); rerr != nil {
+				logger.Errorf("could not mark user %s as revoked. Reason: %s", user.ID, rerr.Error())
+			}
+
+			return domain.UserAccess{}, pservice.ErrUserTokenRevoked
+		}
+
+		return domain.UserAccess{}, err
+	}
+
+	logger.Debugf("user's %s token has been refreshed", user.ID)
+	access := domain.UserAccess{
+		ID:           user.ID,
+		CompanyID:    user.CompanyID,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+		Scope:        token.Scope,
+		ApiDomain:    token.ApiDomain,
+		ExpiresAt:    time.Now().Local().Add(time.Second * time.Duration(token.ExpiresIn-700)).UnixMilli(),
+	}
+
+	if _, err := service.UpdateUser(ctx, access); err != nil {
+		logger.Debugf("could not persist a new user's %s token. Reason: %s. Sending a fallback message!", user.ID, err.Error())
+		return domain.UserAccess{}, err
+	}
+
+	logger.Debugf("user's %s token has been updated", user.ID)
+	return access, nil
+}
+
+func (u UserSelectHandler) GetCompanyUsers(ctx context.Context, cid *string, res *response.CompanyUsersResponse) error {
+	ids, err := u.service.GetCompanyUsers(ctx, *cid)
+	if err != nil {
+		u.logger.Errorf("could not get company %s users. Reason: %s", *cid, err.Error())
+		return err
+	}
+
+	*res = response.CompanyUsersResponse{IDs: ids}
+	return nil
+}
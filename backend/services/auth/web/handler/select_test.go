@@ -23,14 +23,17 @@ import (
 	"testing"
 	"time"
 
-	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/cache"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/adapter"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/domain"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/auth/web/core/service"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
 	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
 	"github.com/stretchr/testify/assert"
+	"go-micro.dev/v4/broker"
 	"golang.org/x/oauth2"
 )
 
@@ -44,10 +47,16 @@ func (e mockEncryptor) Decrypt(ciphertext string, key []byte) (string, error) {
 	return string(ciphertext), nil
 }
 
+func newEventPublisher() shared.EventPublisher {
+	return shared.NewEventPublisher(messaging.BrokerWithOptions{
+		Broker: broker.NewMemoryBroker(),
+	}, &config.ServerConfig{}, log.NewEmptyLogger())
+}
+
 func TestSelectCaching(t *testing.T) {
 	adapter := adapter.NewMemoryUserAdapter()
-	cache := cache.NewCache(&config.CacheConfig{})
-	service := service.NewUserService(adapter, mockEncryptor{}, cache, &oauth2.Config{
+	cache := cache.NewMemoryCache()
+	service := service.NewUserService(adapter, mockEncryptor{}, cache, newEventPublisher(), &oauth2.Config{
 		ClientID:     "mock",
 		ClientSecret: "mock",
 	}, log.NewEmptyLogger())
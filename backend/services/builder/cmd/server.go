@@ -22,6 +22,8 @@ import (
 	pkg "github.com/ONLYOFFICE/onlyoffice-integration-adapters"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/service/rpc"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/adapter"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/service"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/handler"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
@@ -47,9 +49,20 @@ func Server() *cli.Command {
 
 			app := pkg.NewBootstrapper(CONFIG_PATH, pkg.WithModules(
 				rpc.NewService, web.NewConfigRPCServer,
+				adapter.BuildNewRevisionAdapter,
+				adapter.BuildNewDocumentKeyAdapter,
+				service.NewRevisionService,
+				service.NewDocumentKeyService,
 				handler.NewConfigHandler,
+				handler.NewRevisionInsertHandler,
+				handler.NewDocumentKeyHandler,
 				shared.BuildNewOnlyofficeConfig(CONFIG_PATH),
+				shared.BuildNewCallbackStateConfig(CONFIG_PATH),
+				shared.BuildNewDownloadConfig(CONFIG_PATH),
+				shared.NewAuditEmitter,
 				client.NewPipedriveApiClient,
+				client.NewConvertClient,
+				client.NewCommandClient,
 				shared.NewMapFormatManager,
 			)).Bootstrap()
 
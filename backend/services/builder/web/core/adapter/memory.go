@@ -0,0 +1,125 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/port"
+)
+
+type memoryRevisionAdapter struct {
+	kvs map[string][]byte
+}
+
+func NewMemoryRevisionAdapter() port.RevisionServiceAdapter {
+	return &memoryRevisionAdapter{
+		kvs: make(map[string][]byte),
+	}
+}
+
+func (m *memoryRevisionAdapter) save(revision domain.Revision) error {
+	buffer, err := json.Marshal(revision)
+
+	if err != nil {
+		return err
+	}
+
+	m.kvs[revision.FileID] = buffer
+
+	return nil
+}
+
+func (m *memoryRevisionAdapter) InsertRevision(ctx context.Context, revision domain.Revision) error {
+	return m.save(revision)
+}
+
+func (m *memoryRevisionAdapter) SelectRevision(ctx context.Context, fid string) (domain.Revision, error) {
+	buffer, ok := m.kvs[fid]
+	var revision domain.Revision
+
+	if !ok {
+		return revision, ErrNoRevision
+	}
+
+	if err := json.Unmarshal(buffer, &revision); err != nil {
+		return revision, err
+	}
+
+	return revision, nil
+}
+
+func (m *memoryRevisionAdapter) SelectRevisions(ctx context.Context, did string) ([]domain.Revision, error) {
+	revisions := make([]domain.Revision, 0)
+	for _, buffer := range m.kvs {
+		var revision domain.Revision
+		if err := json.Unmarshal(buffer, &revision); err != nil {
+			return nil, err
+		}
+
+		if revision.DocumentID == did {
+			revisions = append(revisions, revision)
+		}
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Version < revisions[j].Version
+	})
+
+	return revisions, nil
+}
+
+func (m *memoryRevisionAdapter) SelectKeyRevision(ctx context.Context, key string) (domain.Revision, error) {
+	var latest domain.Revision
+	found := false
+	for _, buffer := range m.kvs {
+		var revision domain.Revision
+		if err := json.Unmarshal(buffer, &revision); err != nil {
+			return latest, err
+		}
+
+		if revision.Key == key && (!found || revision.Version > latest.Version) {
+			latest, found = revision, true
+		}
+	}
+
+	if !found {
+		return latest, ErrNoRevision
+	}
+
+	return latest, nil
+}
+
+func (m *memoryRevisionAdapter) DeleteRevisions(ctx context.Context, did string) error {
+	for fid, buffer := range m.kvs {
+		var revision domain.Revision
+		if err := json.Unmarshal(buffer, &revision); err != nil {
+			return err
+		}
+
+		if revision.DocumentID == did {
+			delete(m.kvs, fid)
+		}
+	}
+
+	return nil
+}
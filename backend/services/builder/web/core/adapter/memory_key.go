@@ -0,0 +1,102 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/port"
+)
+
+type memoryDocumentKeyAdapter struct {
+	mu   sync.RWMutex
+	keys map[string]domain.DocumentKey
+}
+
+func NewMemoryDocumentKeyAdapter() port.DocumentKeyServiceAdapter {
+	return &memoryDocumentKeyAdapter{
+		keys: make(map[string]domain.DocumentKey),
+	}
+}
+
+func documentKeyID(cid, fid string) string {
+	return cid + ":" + fid
+}
+
+func (m *memoryDocumentKeyAdapter) InsertKey(ctx context.Context, key domain.DocumentKey) (domain.DocumentKey, error) {
+	if err := key.Validate(); err != nil {
+		return key, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := documentKeyID(key.CompanyID, key.FileID)
+	if existing, ok := m.keys[id]; ok {
+		return existing, nil
+	}
+
+	m.keys[id] = key
+	return key, nil
+}
+
+func (m *memoryDocumentKeyAdapter) SaveKey(ctx context.Context, key domain.DocumentKey) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keys[documentKeyID(key.CompanyID, key.FileID)] = key
+	return nil
+}
+
+func (m *memoryDocumentKeyAdapter) SelectKey(ctx context.Context, cid, fid string) (domain.DocumentKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key, ok := m.keys[documentKeyID(cid, fid)]
+	if !ok {
+		return key, ErrNoDocumentKey
+	}
+
+	return key, nil
+}
+
+func (m *memoryDocumentKeyAdapter) SelectKeys(ctx context.Context, key string) ([]domain.DocumentKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]domain.DocumentKey, 0)
+	for _, k := range m.keys {
+		if k.Key == key {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+
+	return keys, nil
+}
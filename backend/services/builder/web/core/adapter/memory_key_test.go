@@ -0,0 +1,85 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var documentKey = domain.DocumentKey{
+	CompanyID: "mock",
+	FileID:    "mock",
+	Key:       "mock",
+	Revision:  1,
+	CreatedAt: time.Now(),
+}
+
+func TestMemoryDocumentKeyAdapter(t *testing.T) {
+	adapter := NewMemoryDocumentKeyAdapter()
+
+	t.Run("insert document key", func(t *testing.T) {
+		key, err := adapter.InsertKey(context.Background(), documentKey)
+		assert.NoError(t, err)
+		assert.Equal(t, documentKey, key)
+	})
+
+	t.Run("insert another key for the same file", func(t *testing.T) {
+		another := documentKey
+		another.Key = "another"
+		key, err := adapter.InsertKey(context.Background(), another)
+		assert.NoError(t, err)
+		assert.Equal(t, "mock", key.Key)
+	})
+
+	t.Run("bind document key to another file", func(t *testing.T) {
+		bound := documentKey
+		bound.FileID = "mock-2"
+		assert.NoError(t, adapter.SaveKey(context.Background(), bound))
+
+		keys, err := adapter.SelectKeys(context.Background(), "mock")
+		assert.NoError(t, err)
+		assert.Len(t, keys, 2)
+	})
+
+	t.Run("rotate document key", func(t *testing.T) {
+		rotated := documentKey
+		rotated.Key, rotated.Revision = "rotated", 2
+		assert.NoError(t, adapter.SaveKey(context.Background(), rotated))
+
+		key, err := adapter.SelectKey(context.Background(), "mock", "mock")
+		assert.NoError(t, err)
+		assert.Equal(t, "rotated", key.Key)
+		assert.Equal(t, 2, key.Revision)
+	})
+
+	t.Run("get key of another company", func(t *testing.T) {
+		_, err := adapter.SelectKey(context.Background(), "another", "mock")
+		assert.ErrorIs(t, err, ErrNoDocumentKey)
+	})
+
+	t.Run("insert invalid document key", func(t *testing.T) {
+		_, err := adapter.InsertKey(context.Background(), domain.DocumentKey{CompanyID: "mock", FileID: "mock"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,94 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var revision = domain.Revision{
+	DocumentID: "mock",
+	FileID:     "mock-2",
+	PreviousID: "mock",
+	Key:        "mock",
+	CompanyID:  "mock",
+	DealID:     "mock",
+	UserID:     "mock",
+	UserName:   "mock",
+	Size:       1024,
+	Version:    2,
+}
+
+func TestMemoryAdapter(t *testing.T) {
+	adapter := NewMemoryRevisionAdapter()
+
+	t.Run("save revision", func(t *testing.T) {
+		assert.NoError(t, adapter.InsertRevision(context.Background(), revision))
+	})
+
+	t.Run("save the next revision", func(t *testing.T) {
+		assert.NoError(t, adapter.InsertRevision(context.Background(), domain.Revision{
+			DocumentID: "mock",
+			FileID:     "mock-3",
+			PreviousID: "mock-2",
+			Key:        "mock",
+			CompanyID:  "mock",
+			UserID:     "mock",
+			Version:    3,
+		}))
+	})
+
+	t.Run("get revision by file id", func(t *testing.T) {
+		r, err := adapter.SelectRevision(context.Background(), "mock-2")
+		assert.NoError(t, err)
+		assert.Equal(t, revision, r)
+	})
+
+	t.Run("get revisions by document id", func(t *testing.T) {
+		r, err := adapter.SelectRevisions(context.Background(), "mock")
+		assert.NoError(t, err)
+		assert.Len(t, r, 2)
+		assert.Equal(t, 2, r[0].Version)
+		assert.Equal(t, 3, r[1].Version)
+	})
+
+	t.Run("get the latest revision by document key", func(t *testing.T) {
+		r, err := adapter.SelectKeyRevision(context.Background(), "mock")
+		assert.NoError(t, err)
+		assert.Equal(t, "mock-3", r.FileID)
+	})
+
+	t.Run("get revision by unknown document key", func(t *testing.T) {
+		_, err := adapter.SelectKeyRevision(context.Background(), "unknown")
+		assert.ErrorIs(t, err, ErrNoRevision)
+	})
+
+	t.Run("delete revisions by document id", func(t *testing.T) {
+		assert.NoError(t, adapter.DeleteRevisions(context.Background(), "mock"))
+	})
+
+	t.Run("get invalid revision", func(t *testing.T) {
+		_, err := adapter.SelectRevision(context.Background(), "mock-2")
+		assert.Error(t, err)
+	})
+}
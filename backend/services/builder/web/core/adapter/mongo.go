@@ -0,0 +1,172 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/port"
+	"github.com/kamva/mgm/v3"
+	"github.com/kamva/mgm/v3/operator"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type revisionCollection struct {
+	mgm.DefaultModel `bson:",inline"`
+	DocumentID       string   `json:"document_id" bson:"document_id"`
+	FileID           string   `json:"file_id" bson:"file_id"`
+	PreviousID       string   `json:"previous_id" bson:"previous_id"`
+	Key              string   `json:"key" bson:"key"`
+	CompanyID        string   `json:"company_id" bson:"company_id"`
+	DealID           string   `json:"deal_id" bson:"deal_id"`
+	UserID           string   `json:"user_id" bson:"user_id"`
+	UserName         string   `json:"user_name" bson:"user_name"`
+	Size             int64    `json:"size" bson:"size"`
+	Checksum         string   `json:"checksum" bson:"checksum"`
+	Version          int      `json:"version" bson:"version"`
+	Forcesave        bool     `json:"forcesave" bson:"forcesave"`
+	Contributors     []string `json:"contributors" bson:"contributors"`
+}
+
+func (r revisionCollection) toDomain() domain.Revision {
+	return domain.Revision{
+		DocumentID:   r.DocumentID,
+		FileID:       r.FileID,
+		PreviousID:   r.PreviousID,
+		Key:          r.Key,
+		CompanyID:    r.CompanyID,
+		DealID:       r.DealID,
+		UserID:       r.UserID,
+		UserName:     r.UserName,
+		Size:         r.Size,
+		Checksum:     r.Checksum,
+		Version:      r.Version,
+		Forcesave:    r.Forcesave,
+		Contributors: r.Contributors,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+type mongoRevisionAdapter struct {
+}
+
+func NewMongoRevisionAdapter(url string) port.RevisionServiceAdapter {
+	if err := mgm.SetDefaultConfig(
+		&mgm.Config{CtxTimeout: 3 * time.Second}, "pipedrive",
+		options.Client().ApplyURI(url),
+	); err != nil {
+		log.Fatalf("mongo initialization error: %s", err.Error())
+	}
+
+	return &mongoRevisionAdapter{}
+}
+
+func (m *mongoRevisionAdapter) InsertRevision(ctx context.Context, revision domain.Revision) error {
+	if err := revision.Validate(); err != nil {
+		return err
+	}
+
+	return mgm.Coll(&revisionCollection{}).CreateWithCtx(ctx, &revisionCollection{
+		DocumentID:   revision.DocumentID,
+		FileID:       revision.FileID,
+		PreviousID:   revision.PreviousID,
+		Key:          revision.Key,
+		CompanyID:    revision.CompanyID,
+		DealID:       revision.DealID,
+		UserID:       revision.UserID,
+		UserName:     revision.UserName,
+		Size:         revision.Size,
+		Checksum:     revision.Checksum,
+		Version:      revision.Version,
+		Forcesave:    revision.Forcesave,
+		Contributors: revision.Contributors,
+	})
+}
+
+func (m *mongoRevisionAdapter) SelectRevision(ctx context.Context, fid string) (domain.Revision, error) {
+	fid = strings.TrimSpace(fid)
+
+	if fid == "" {
+		return domain.Revision{}, ErrInvalidFileID
+	}
+
+	revision := &revisionCollection{}
+	if err := mgm.Coll(revision).FirstWithCtx(ctx, bson.M{"file_id": fid}, revision); err != nil {
+		return domain.Revision{}, err
+	}
+
+	return revision.toDomain(), nil
+}
+
+func (m *mongoRevisionAdapter) SelectRevisions(ctx context.Context, did string) ([]domain.Revision, error) {
+	did = strings.TrimSpace(did)
+
+	if did == "" {
+		return nil, ErrInvalidDocumentID
+	}
+
+	var results []revisionCollection
+	if err := mgm.Coll(&revisionCollection{}).SimpleFindWithCtx(
+		ctx, &results, bson.M{"document_id": did},
+		options.Find().SetSort(bson.M{"version": 1}),
+	); err != nil {
+		return nil, err
+	}
+
+	revisions := make([]domain.Revision, 0, len(results))
+	for _, result := range results {
+		revisions = append(revisions, result.toDomain())
+	}
+
+	return revisions, nil
+}
+
+func (m *mongoRevisionAdapter) SelectKeyRevision(ctx context.Context, key string) (domain.Revision, error) {
+	key = strings.TrimSpace(key)
+
+	if key == "" {
+		return domain.Revision{}, ErrInvalidDocumentKey
+	}
+
+	revision := &revisionCollection{}
+	if err := mgm.Coll(revision).FindOne(
+		ctx, bson.M{"key": key},
+		options.FindOne().SetSort(bson.M{"version": -1}),
+	).Decode(revision); err != nil {
+		return domain.Revision{}, err
+	}
+
+	return revision.toDomain(), nil
+}
+
+func (m *mongoRevisionAdapter) DeleteRevisions(ctx context.Context, did string) error {
+	did = strings.TrimSpace(did)
+
+	if did == "" {
+		return ErrInvalidDocumentID
+	}
+
+	_, err := mgm.Coll(&revisionCollection{}).DeleteMany(ctx, bson.M{"document_id": bson.M{operator.Eq: did}})
+	return err
+}
@@ -0,0 +1,176 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/port"
+	"github.com/kamva/mgm/v3"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type documentKeyCollection struct {
+	mgm.DefaultModel `bson:",inline"`
+	CompanyID        string `json:"company_id" bson:"company_id"`
+	FileID           string `json:"file_id" bson:"file_id"`
+	Key              string `json:"key" bson:"key"`
+	Revision         int    `json:"revision" bson:"revision"`
+}
+
+func (d documentKeyCollection) toDomain() domain.DocumentKey {
+	return domain.DocumentKey{
+		CompanyID: d.CompanyID,
+		FileID:    d.FileID,
+		Key:       d.Key,
+		Revision:  d.Revision,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+type mongoDocumentKeyAdapter struct {
+}
+
+func NewMongoDocumentKeyAdapter(url string) port.DocumentKeyServiceAdapter {
+	if err := mgm.SetDefaultConfig(
+		&mgm.Config{CtxTimeout: 3 * time.Second}, "pipedrive",
+		options.Client().ApplyURI(url),
+	); err != nil {
+		log.Fatalf("mongo initialization error: %s", err.Error())
+	}
+
+	// The unique index makes concurrent upserts of the same file key safe.
+	if _, err := mgm.Coll(&documentKeyCollection{}).Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "company_id", Value: 1}, {Key: "file_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "key", Value: 1}},
+		},
+	}); err != nil {
+		log.Printf("could not create document key indexes: %s", err.Error())
+	}
+
+	return &mongoDocumentKeyAdapter{}
+}
+
+// InsertKey relies on an upsert so that concurrent openers of the same file
+// across builder instances end up with a single key.
+func (m *mongoDocumentKeyAdapter) InsertKey(ctx context.Context, key domain.DocumentKey) (domain.DocumentKey, error) {
+	if err := key.Validate(); err != nil {
+		return key, err
+	}
+
+	now := time.Now().UTC()
+	result := &documentKeyCollection{}
+	if err := mgm.Coll(result).FindOneAndUpdate(
+		ctx,
+		bson.M{"company_id": key.CompanyID, "file_id": key.FileID},
+		bson.M{"$setOnInsert": bson.M{
+			"company_id": key.CompanyID,
+			"file_id":    key.FileID,
+			"key":        key.Key,
+			"revision":   key.Revision,
+			"created_at": now,
+			"updated_at": now,
+		}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(result); err != nil {
+		return key, err
+	}
+
+	return result.toDomain(), nil
+}
+
+func (m *mongoDocumentKeyAdapter) SaveKey(ctx context.Context, key domain.DocumentKey) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	_, err := mgm.Coll(&documentKeyCollection{}).UpdateOne(
+		ctx,
+		bson.M{"company_id": key.CompanyID, "file_id": key.FileID},
+		bson.M{
+			"$set": bson.M{
+				"key":        key.Key,
+				"revision":   key.Revision,
+				"updated_at": now,
+			},
+			"$setOnInsert": bson.M{
+				"created_at": now,
+			},
+		},
+		options.Update().SetUpsert(true),
+	)
+
+	return err
+}
+
+func (m *mongoDocumentKeyAdapter) SelectKey(ctx context.Context, cid, fid string) (domain.DocumentKey, error) {
+	cid, fid = strings.TrimSpace(cid), strings.TrimSpace(fid)
+	if cid == "" {
+		return domain.DocumentKey{}, ErrInvalidCompanyID
+	}
+
+	if fid == "" {
+		return domain.DocumentKey{}, ErrInvalidFileID
+	}
+
+	result := &documentKeyCollection{}
+	if err := mgm.Coll(result).FirstWithCtx(ctx, bson.M{"company_id": cid, "file_id": fid}, result); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.DocumentKey{}, ErrNoDocumentKey
+		}
+
+		return domain.DocumentKey{}, err
+	}
+
+	return result.toDomain(), nil
+}
+
+func (m *mongoDocumentKeyAdapter) SelectKeys(ctx context.Context, key string) ([]domain.DocumentKey, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, ErrInvalidDocumentKey
+	}
+
+	var results []documentKeyCollection
+	if err := mgm.Coll(&documentKeyCollection{}).SimpleFindWithCtx(
+		ctx, &results, bson.M{"key": key},
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	); err != nil {
+		return nil, err
+	}
+
+	keys := make([]domain.DocumentKey, 0, len(results))
+	for _, result := range results {
+		keys = append(keys, result.toDomain())
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,80 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// DocumentKey is a document server key of a pipedrive file. Every rotation
+// bumps the revision and issues a new key.
+type DocumentKey struct {
+	CompanyID string    `json:"company_id" mapstructure:"company_id"`
+	FileID    string    `json:"file_id" mapstructure:"file_id"`
+	Key       string    `json:"key" mapstructure:"key"`
+	Revision  int       `json:"revision" mapstructure:"revision"`
+	CreatedAt time.Time `json:"created_at" mapstructure:"created_at"`
+}
+
+func (k DocumentKey) ToJSON() []byte {
+	buf, _ := json.Marshal(k)
+	return buf
+}
+
+func (k *DocumentKey) Validate() error {
+	k.CompanyID = strings.TrimSpace(k.CompanyID)
+	k.FileID = strings.TrimSpace(k.FileID)
+	k.Key = strings.TrimSpace(k.Key)
+
+	if k.CompanyID == "" {
+		return &InvalidModelFieldError{
+			Model:  "DocumentKey",
+			Field:  "CompanyID",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if k.FileID == "" {
+		return &InvalidModelFieldError{
+			Model:  "DocumentKey",
+			Field:  "FileID",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if k.Key == "" || len(k.Key) > 128 {
+		return &InvalidModelFieldError{
+			Model:  "DocumentKey",
+			Field:  "Key",
+			Reason: "Should not be empty or longer than 128 characters",
+		}
+	}
+
+	if k.Revision < 1 {
+		return &InvalidModelFieldError{
+			Model:  "DocumentKey",
+			Field:  "Revision",
+			Reason: "Invalid revision value. Expected revision > 0",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package domain
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var checksumPattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+type Revision struct {
+	DocumentID   string    `json:"document_id" mapstructure:"document_id"`
+	FileID       string    `json:"file_id" mapstructure:"file_id"`
+	PreviousID   string    `json:"previous_id" mapstructure:"previous_id"`
+	Key          string    `json:"key" mapstructure:"key"`
+	CompanyID    string    `json:"company_id" mapstructure:"company_id"`
+	DealID       string    `json:"deal_id" mapstructure:"deal_id"`
+	UserID       string    `json:"user_id" mapstructure:"user_id"`
+	UserName     string    `json:"user_name" mapstructure:"user_name"`
+	Size         int64     `json:"size" mapstructure:"size"`
+	Checksum     string    `json:"checksum" mapstructure:"checksum"`
+	Version      int       `json:"version" mapstructure:"version"`
+	Forcesave    bool      `json:"forcesave" mapstructure:"forcesave"`
+	Contributors []string  `json:"contributors" mapstructure:"contributors"`
+	CreatedAt    time.Time `json:"created_at" mapstructure:"created_at"`
+}
+
+func (r Revision) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
+
+func (r *Revision) Validate() error {
+	r.DocumentID = strings.TrimSpace(r.DocumentID)
+	r.FileID = strings.TrimSpace(r.FileID)
+	r.PreviousID = strings.TrimSpace(r.PreviousID)
+	r.Key = strings.TrimSpace(r.Key)
+	r.CompanyID = strings.TrimSpace(r.CompanyID)
+	r.UserID = strings.TrimSpace(r.UserID)
+
+	if r.DocumentID == "" {
+		return &InvalidModelFieldError{
+			Model:  "Revision",
+			Field:  "DocumentID",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if r.FileID == "" {
+		return &InvalidModelFieldError{
+			Model:  "Revision",
+			Field:  "FileID",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if r.Key == "" {
+		return &InvalidModelFieldError{
+			Model:  "Revision",
+			Field:  "Key",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if r.CompanyID == "" {
+		return &InvalidModelFieldError{
+			Model:  "Revision",
+			Field:  "CompanyID",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if r.UserID == "" {
+		return &InvalidModelFieldError{
+			Model:  "Revision",
+			Field:  "UserID",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if r.Size < 0 {
+		return &InvalidModelFieldError{
+			Model:  "Revision",
+			Field:  "Size",
+			Reason: "Should not be negative",
+		}
+	}
+
+	if r.Checksum != "" && !checksumPattern.MatchString(r.Checksum) {
+		return &InvalidModelFieldError{
+			Model:  "Revision",
+			Field:  "Checksum",
+			Reason: "Should be a hex encoded sha-256 sum",
+		}
+	}
+
+	if r.Version < 2 {
+		return &InvalidModelFieldError{
+			Model:  "Revision",
+			Field:  "Version",
+			Reason: "Invalid version value. Expected version > 1",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package port
+
+import (
+	"context"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+)
+
+type RevisionService interface {
+	CreateRevision(ctx context.Context, revision domain.Revision) (domain.Revision, error)
+	GetRevisions(ctx context.Context, fid string) ([]domain.Revision, error)
+}
+
+type DocumentKeyService interface {
+	AcquireKey(ctx context.Context, cid, fid string) (domain.DocumentKey, error)
+	GetKey(ctx context.Context, cid, fid string) (domain.DocumentKey, error)
+	BindKey(ctx context.Context, cid, fid, key string) (domain.DocumentKey, error)
+	RotateKey(ctx context.Context, key string) error
+}
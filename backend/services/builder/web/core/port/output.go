@@ -0,0 +1,40 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package port
+
+import (
+	"context"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+)
+
+type RevisionServiceAdapter interface {
+	InsertRevision(ctx context.Context, revision domain.Revision) error
+	SelectRevision(ctx context.Context, fid string) (domain.Revision, error)
+	SelectRevisions(ctx context.Context, did string) ([]domain.Revision, error)
+	SelectKeyRevision(ctx context.Context, key string) (domain.Revision, error)
+	DeleteRevisions(ctx context.Context, did string) error
+}
+
+type DocumentKeyServiceAdapter interface {
+	InsertKey(ctx context.Context, key domain.DocumentKey) (domain.DocumentKey, error)
+	SaveKey(ctx context.Context, key domain.DocumentKey) error
+	SelectKey(ctx context.Context, cid, fid string) (domain.DocumentKey, error)
+	SelectKeys(ctx context.Context, key string) ([]domain.DocumentKey, error)
+}
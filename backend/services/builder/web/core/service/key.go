@@ -0,0 +1,171 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/port"
+	"golang.org/x/sync/singleflight"
+)
+
+type documentKeyService struct {
+	adapter port.DocumentKeyServiceAdapter
+	group   *singleflight.Group
+	logger  plog.Logger
+}
+
+func NewDocumentKeyService(
+	adapter port.DocumentKeyServiceAdapter,
+	logger plog.Logger,
+) port.DocumentKeyService {
+	return documentKeyService{
+		adapter: adapter,
+		group:   new(singleflight.Group),
+		logger:  logger,
+	}
+}
+
+// newDocumentKey derives a key from the file id and its revision. A random
+// salt guarantees that a key is never issued twice, even if the store is lost.
+func newDocumentKey(cid, fid string, revision int) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%s:%d:%s", cid, fid, revision, hex.EncodeToString(salt))))
+	return hex.EncodeToString(sum[:])[:32], nil
+}
+
+func (s documentKeyService) AcquireKey(ctx context.Context, cid, fid string) (domain.DocumentKey, error) {
+	cid, fid = strings.TrimSpace(cid), strings.TrimSpace(fid)
+	if cid == "" || fid == "" {
+		return domain.DocumentKey{}, &InvalidServiceParameterError{
+			Name:   "CID/FID",
+			Reason: "Should not be blank",
+		}
+	}
+
+	res, err, _ := s.group.Do(cid+":"+fid, func() (interface{}, error) {
+		if key, err := s.adapter.SelectKey(ctx, cid, fid); err == nil {
+			return key, nil
+		}
+
+		generated, err := newDocumentKey(cid, fid, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		s.logger.Debugf("issuing a new document key for file %s of company %s", fid, cid)
+		return s.adapter.InsertKey(ctx, domain.DocumentKey{
+			CompanyID: cid,
+			FileID:    fid,
+			Key:       generated,
+			Revision:  1,
+			CreatedAt: time.Now(),
+		})
+	})
+	if err != nil {
+		return domain.DocumentKey{}, err
+	}
+
+	return res.(domain.DocumentKey), nil
+}
+
+// GetKey returns the key the file is currently edited with without issuing a new one.
+func (s documentKeyService) GetKey(ctx context.Context, cid, fid string) (domain.DocumentKey, error) {
+	cid, fid = strings.TrimSpace(cid), strings.TrimSpace(fid)
+	if cid == "" || fid == "" {
+		return domain.DocumentKey{}, &InvalidServiceParameterError{
+			Name:   "CID/FID",
+			Reason: "Should not be blank",
+		}
+	}
+
+	return s.adapter.SelectKey(ctx, cid, fid)
+}
+
+func (s documentKeyService) BindKey(ctx context.Context, cid, fid, key string) (domain.DocumentKey, error) {
+	cid, fid, key = strings.TrimSpace(cid), strings.TrimSpace(fid), strings.TrimSpace(key)
+	if cid == "" || fid == "" || key == "" {
+		return domain.DocumentKey{}, &InvalidServiceParameterError{
+			Name:   "CID/FID/Key",
+			Reason: "Should not be blank",
+		}
+	}
+
+	revision := 1
+	if keys, err := s.adapter.SelectKeys(ctx, key); err == nil && len(keys) > 0 {
+		revision = keys[0].Revision
+	}
+
+	bound := domain.DocumentKey{
+		CompanyID: cid,
+		FileID:    fid,
+		Key:       key,
+		Revision:  revision,
+		CreatedAt: time.Now(),
+	}
+
+	s.logger.Debugf("binding document key %s to file %s", key, fid)
+	if err := s.adapter.SaveKey(ctx, bound); err != nil {
+		return bound, err
+	}
+
+	return bound, nil
+}
+
+func (s documentKeyService) RotateKey(ctx context.Context, key string) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return &InvalidServiceParameterError{
+			Name:   "Key",
+			Reason: "Should not be blank",
+		}
+	}
+
+	keys, err := s.adapter.SelectKeys(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		generated, err := newDocumentKey(k.CompanyID, k.FileID, k.Revision+1)
+		if err != nil {
+			return err
+		}
+
+		s.logger.Debugf("rotating document key of file %s to revision %d", k.FileID, k.Revision+1)
+		k.Key, k.Revision = generated, k.Revision+1
+		if err := s.adapter.SaveKey(ctx, k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
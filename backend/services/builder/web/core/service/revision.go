@@ -0,0 +1,115 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/port"
+)
+
+type revisionService struct {
+	adapter port.RevisionServiceAdapter
+	logger  plog.Logger
+}
+
+func NewRevisionService(
+	adapter port.RevisionServiceAdapter,
+	logger plog.Logger,
+) port.RevisionService {
+	return revisionService{
+		adapter: adapter,
+		logger:  logger,
+	}
+}
+
+func (s revisionService) CreateRevision(ctx context.Context, revision domain.Revision) (domain.Revision, error) {
+	revision.PreviousID = strings.TrimSpace(revision.PreviousID)
+	if revision.PreviousID == "" {
+		return revision, &InvalidServiceParameterError{
+			Name:   "PreviousID",
+			Reason: "Should not be blank",
+		}
+	}
+
+	// A session may be saved several times while it is open. Every save
+	// supersedes the file produced by the previous save of the same session.
+	if latest, err := s.adapter.SelectKeyRevision(ctx, revision.Key); err == nil && latest.CompanyID == revision.CompanyID {
+		s.logger.Debugf("document key %s has already been saved as file %s", revision.Key, latest.FileID)
+		revision.PreviousID = latest.FileID
+	}
+
+	s.logger.Debugf("trying to find a revision chain for file %s", revision.PreviousID)
+	if previous, err := s.adapter.SelectRevision(ctx, revision.PreviousID); err == nil {
+		revision.DocumentID = previous.DocumentID
+		revision.Version = previous.Version + 1
+	} else {
+		s.logger.Debugf("file %s is the first version of a new revision chain", revision.PreviousID)
+		revision.DocumentID = revision.PreviousID
+		revision.Version = 2
+	}
+
+	revision.CreatedAt = time.Now()
+	if err := revision.Validate(); err != nil {
+		return revision, err
+	}
+
+	s.logger.Debugf("revision %d of document %s is valid. Persisting to database", revision.Version, revision.DocumentID)
+	if err := s.adapter.InsertRevision(ctx, revision); err != nil {
+		return revision, err
+	}
+
+	return revision, nil
+}
+
+func (s revisionService) GetRevisions(ctx context.Context, fid string) ([]domain.Revision, error) {
+	id := strings.TrimSpace(fid)
+	s.logger.Debugf("trying to select revisions of file %s", id)
+
+	if id == "" {
+		return nil, &InvalidServiceParameterError{
+			Name:   "FID",
+			Reason: "Should not be blank",
+		}
+	}
+
+	current, err := s.adapter.SelectRevision(ctx, id)
+	if err != nil {
+		s.logger.Debugf("file %s does not have any revisions", id)
+		return []domain.Revision{}, nil
+	}
+
+	revisions, err := s.adapter.SelectRevisions(ctx, current.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]domain.Revision, 0, len(revisions))
+	for _, revision := range revisions {
+		if revision.Version <= current.Version {
+			history = append(history, revision)
+		}
+	}
+
+	return history, nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
 	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/port"
 	shared "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
 	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
@@ -50,32 +51,53 @@ var (
 )
 
 type ConfigHandler struct {
-	client        client.Client
-	apiClient     pclient.PipedriveApiClient
-	jwtManager    crypto.JwtManager
-	config        *config.ServerConfig
-	onlyoffice    *shared.OnlyofficeConfig
-	logger        plog.Logger
-	formatManager shared.FormatManager
+	client          client.Client
+	revisionService port.RevisionService
+	keyService      port.DocumentKeyService
+	apiClient       pclient.PipedriveApiClient
+	convertClient   pclient.ConvertClient
+	commandClient   pclient.CommandClient
+	jwtManager      crypto.JwtManager
+	config          *config.ServerConfig
+	onlyoffice      *shared.OnlyofficeConfig
+	state           *shared.CallbackStateConfig
+	download        *shared.DownloadConfig
+	logger          plog.Logger
+	formatManager   shared.FormatManager
+	audit           shared.AuditEmitter
 }
 
 func NewConfigHandler(
 	client client.Client,
+	revisionService port.RevisionService,
+	keyService port.DocumentKeyService,
 	jwtManager crypto.JwtManager,
 	apiClient pclient.PipedriveApiClient,
+	convertClient pclient.ConvertClient,
+	commandClient pclient.CommandClient,
 	config *config.ServerConfig,
 	onlyoffice *shared.OnlyofficeConfig,
+	state *shared.CallbackStateConfig,
+	download *shared.DownloadConfig,
 	formatManager shared.FormatManager,
+	audit shared.AuditEmitter,
 	logger plog.Logger,
 ) ConfigHandler {
 	return ConfigHandler{
-		client:        client,
-		apiClient:     apiClient,
-		jwtManager:    jwtManager,
-		config:        config,
-		onlyoffice:    onlyoffice,
-		logger:        logger,
-		formatManager: formatManager,
+		client:          client,
+		revisionService: revisionService,
+		keyService:      keyService,
+		apiClient:       apiClient,
+		convertClient:   convertClient,
+		commandClient:   commandClient,
+		jwtManager:      jwtManager,
+		config:          config,
+		onlyoffice:      onlyoffice,
+		state:           state,
+		download:        download,
+		logger:          logger,
+		formatManager:   formatManager,
+		audit:           audit,
 	}
 }
 
@@ -92,8 +114,104 @@ func (c ConfigHandler) isDemoModeValid(settings response.DocSettingsResponse) bo
 	return settings.DemoStarted.After(staleDate)
 }
 
+func (c ConfigHandler) getSettings(ctx context.Context, cid int) (response.DocSettingsResponse, error) {
+	var docs response.DocSettingsResponse
+	if err := c.client.Call(
+		ctx,
+		c.client.NewRequest(
+			fmt.Sprintf("%s:settings", c.config.Namespace),
+			"SettingsSelectHandler.GetSettings",
+			fmt.Sprint(cid),
+		),
+		&docs,
+	); err != nil {
+		c.logger.Debugf("could not document server settings: %s", err.Error())
+		return docs, err
+	}
+
+	if c.isDemoModeValid(docs) {
+		if c.onlyoffice.Onlyoffice.Demo.DocumentServerURL == "" ||
+			c.onlyoffice.Onlyoffice.Demo.DocumentServerSecret == "" ||
+			c.onlyoffice.Onlyoffice.Demo.DocumentServerHeader == "" {
+			c.logger.Errorf("demo mode is enabled but demo credentials are not configured")
+			return docs, ErrNoSettingsFound
+		}
+
+		c.logger.Debugf("using demo mode for company %d", cid)
+		docs.DocAddress = c.onlyoffice.Onlyoffice.Demo.DocumentServerURL
+		docs.DocSecret = c.onlyoffice.Onlyoffice.Demo.DocumentServerSecret
+		docs.DocHeader = c.onlyoffice.Onlyoffice.Demo.DocumentServerHeader
+	} else {
+		if docs.DocAddress == "" || docs.DocSecret == "" || docs.DocHeader == "" {
+			c.logger.Debugf("no settings found and demo mode not valid")
+			return docs, ErrNoSettingsFound
+		}
+		c.logger.Debugf("using regular document server settings for company %d", cid)
+	}
+
+	return docs, nil
+}
+
+func (c ConfigHandler) getDownloadURL(ctx context.Context, user response.UserResponse, fileID string) (string, error) {
+	dreq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/files/%s/download", user.ApiDomain, fileID), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	dreq.Header.Add("Authorization", fmt.Sprintf("Bearer %s", user.AccessToken))
+	resp, err := defaultClient.Do(dreq)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if resp != nil && resp.Body != nil {
+			resp.Body.Close()
+		}
+	}()
+
+	// Superseded files are gone once the delete save strategy has retired them.
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return "", ErrFileUnavailable
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", &pclient.UnexpectedStatusCodeError{
+			Action: "download file",
+			Code:   resp.StatusCode,
+		}
+	}
+
+	return location, nil
+}
+
+// getDocumentURL points the document server to the gateway download proxy so that
+// the link does not expire while the editor is open. Presigned pipedrive links are
+// only used when no gateway is configured.
+func (c ConfigHandler) getDocumentURL(ctx context.Context, user response.UserResponse, usr model.User, fileID string) (string, error) {
+	if c.onlyoffice.Onlyoffice.Builder.GatewayURL == "" {
+		return c.getDownloadURL(ctx, user, fileID)
+	}
+
+	token, err := shared.SignDownloadToken(c.jwtManager, c.download.Download.Secret, shared.DownloadToken{
+		CompanyID: usr.CompanyID,
+		UserID:    usr.ID,
+		FileID:    fileID,
+	}, time.Duration(c.download.Download.TTL)*time.Second)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/files/proxy?token=%s",
+		strings.TrimSuffix(c.onlyoffice.Onlyoffice.Builder.GatewayURL, "/"), url.QueryEscape(token),
+	), nil
+}
+
 func (c ConfigHandler) processConfig(user response.UserResponse, req request.BuildConfigRequest, ctx context.Context) (response.BuildConfigResponse, error) {
 	var config response.BuildConfigResponse
+	if err := req.Parent.Validate(); err != nil {
+		return config, err
+	}
 
 	tctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -102,6 +220,7 @@ func (c ConfigHandler) processConfig(user response.UserResponse, req request.Bui
 
 	var usr model.User
 	var settings response.DocSettingsResponse
+	var key string
 
 	g.Go(func() error {
 		u, err := c.apiClient.GetMe(gctx, model.Token{
@@ -120,41 +239,23 @@ func (c ConfigHandler) processConfig(user response.UserResponse, req request.Bui
 	})
 
 	g.Go(func() error {
-		var docs response.DocSettingsResponse
-		if err := c.client.Call(
-			gctx,
-			c.client.NewRequest(
-				fmt.Sprintf("%s:settings", c.config.Namespace),
-				"SettingsSelectHandler.GetSettings",
-				fmt.Sprint(req.CID),
-			),
-			&docs,
-		); err != nil {
-			c.logger.Debugf("could not document server settings: %s", err.Error())
+		docs, err := c.getSettings(gctx, req.CID)
+		if err != nil {
 			return err
 		}
 
-		if c.isDemoModeValid(docs) {
-			if c.onlyoffice.Onlyoffice.Demo.DocumentServerURL == "" ||
-				c.onlyoffice.Onlyoffice.Demo.DocumentServerSecret == "" ||
-				c.onlyoffice.Onlyoffice.Demo.DocumentServerHeader == "" {
-				c.logger.Errorf("demo mode is enabled but demo credentials are not configured")
-				return ErrNoSettingsFound
-			}
-
-			c.logger.Debugf("using demo mode for company %d", req.CID)
-			docs.DocAddress = c.onlyoffice.Onlyoffice.Demo.DocumentServerURL
-			docs.DocSecret = c.onlyoffice.Onlyoffice.Demo.DocumentServerSecret
-			docs.DocHeader = c.onlyoffice.Onlyoffice.Demo.DocumentServerHeader
-		} else {
-			if docs.DocAddress == "" || docs.DocSecret == "" || docs.DocHeader == "" {
-				c.logger.Debugf("no settings found and demo mode not valid")
-				return ErrNoSettingsFound
-			}
-			c.logger.Debugf("using regular document server settings for company %d", req.CID)
+		settings = docs
+		return nil
+	})
+
+	g.Go(func() error {
+		docKey, err := c.keyService.AcquireKey(gctx, fmt.Sprint(req.CID), req.FileID)
+		if err != nil {
+			c.logger.Debugf("could not acquire file %s document key: %s", req.FileID, err.Error())
+			return err
 		}
 
-		settings = docs
+		key = docKey.Key
 		return nil
 	})
 
@@ -168,22 +269,23 @@ func (c ConfigHandler) processConfig(user response.UserResponse, req request.Bui
 		t = "mobile"
 	}
 
-	dreq, err := http.NewRequestWithContext(tctx, "GET", fmt.Sprintf("%s/files/%s/download", user.ApiDomain, req.FileID), nil)
+	location, err := c.getDocumentURL(tctx, user, usr, req.FileID)
 	if err != nil {
-		return config, fmt.Errorf("failed to create request: %w", err)
+		return config, err
 	}
-	dreq.Header.Add("Authorization", fmt.Sprintf("Bearer %s", user.AccessToken))
-	resp, err := defaultClient.Do(dreq)
+
+	filename := c.formatManager.EscapeFileName(req.Filename)
+	state, err := shared.SignCallbackState(c.jwtManager, c.state.State.Secret, shared.CallbackState{
+		CompanyID: usr.CompanyID,
+		Parent:    req.Parent.String(),
+		FileID:    req.FileID,
+		Filename:  filename,
+		Key:       key,
+	}, time.Duration(c.state.State.TTL)*time.Second)
 	if err != nil {
-		return config, fmt.Errorf("failed to execute request: %w", err)
+		return config, err
 	}
-	defer func() {
-		if resp != nil && resp.Body != nil {
-			resp.Body.Close()
-		}
-	}()
 
-	filename := c.formatManager.EscapeFileName(req.Filename)
 	theme := "default-light"
 	if req.Dark {
 		theme = "default-dark"
@@ -191,20 +293,18 @@ func (c ConfigHandler) processConfig(user response.UserResponse, req request.Bui
 
 	config = response.BuildConfigResponse{
 		Document: response.Document{
-			Key:   req.DocKey,
+			Key:   key,
 			Title: filename,
-			URL:   resp.Header.Get("Location"),
+			URL:   location,
 		},
 		EditorConfig: response.EditorConfig{
 			User: response.User{
-				ID:   fmt.Sprint(usr.ID + usr.CompanyID),
+				ID:   shared.NewUserIdentity(usr.ID, usr.CompanyID).String(),
 				Name: usr.Name,
 			},
 			CallbackURL: fmt.Sprintf(
-				"%s/callback?cid=%d&did=%s&fid=%s&filename=%s",
-				c.onlyoffice.Onlyoffice.Builder.CallbackURL,
-				usr.CompanyID, req.Deal, req.FileID,
-				url.QueryEscape(filename),
+				"%s/callback?state=%s",
+				c.onlyoffice.Onlyoffice.Builder.CallbackURL, url.QueryEscape(state),
 			),
 			Customization: response.Customization{
 				Goback: response.Goback{
@@ -235,16 +335,25 @@ func (c ConfigHandler) processConfig(user response.UserResponse, req request.Bui
 		fileType = format.Type
 		isEditable = format.IsEditable()
 
+		policy := settings.Permissions
+		forceReview := policy.ForceReview && isEditable && fileType == "word"
 		config.Document.Permissions = response.Permissions{
-			Edit:                 isEditable,
-			Comment:              true,
-			Download:             true,
-			Print:                false,
-			Review:               false,
-			Copy:                 true,
-			ModifyContentControl: true,
-			ModifyFilter:         true,
+			Edit:                    isEditable && !forceReview,
+			Comment:                 true,
+			Download:                !policy.DisableDownload,
+			Print:                   policy.Print,
+			Review:                  forceReview,
+			Copy:                    true,
+			EditCommentAuthorOnly:   policy.EditCommentAuthorOnly,
+			DeleteCommentAuthorOnly: policy.DeleteCommentAuthorOnly,
+			ModifyContentControl:    true,
+			ModifyFilter:            true,
 		}
+		config.Document.Permissions = applyMode(
+			config.Document.Permissions,
+			resolveMode(usr.Access, settings.Roles),
+			fileType,
+		)
 		config.DocumentType = fileType
 	}
 
@@ -264,12 +373,12 @@ func (c ConfigHandler) BuildConfig(ctx context.Context, payload request.BuildCon
 
 	req := c.client.NewRequest(
 		fmt.Sprintf("%s:auth", c.config.Namespace), "UserSelectHandler.GetUser",
-		fmt.Sprint(payload.UID+payload.CID),
+		shared.NewUserIdentity(payload.UID, payload.CID).String(),
 	)
 
 	var ures response.UserResponse
 	if err := c.client.Call(ctx, req, &ures); err != nil {
-		c.logger.Debugf("could not get user %d access info: %s", payload.UID+payload.CID, err.Error())
+		c.logger.Debugf("could not get user %s access info: %s", shared.NewUserIdentity(payload.UID, payload.CID).String(), err.Error())
 		return err
 	}
 
@@ -278,6 +387,19 @@ func (c ConfigHandler) BuildConfig(ctx context.Context, payload request.BuildCon
 		return err
 	}
 
+	c.audit.Emit(request.AuditEvent{
+		Type:      request.AuditConfigBuilt,
+		CompanyID: fmt.Sprint(payload.CID),
+		UserID:    fmt.Sprint(payload.UID),
+		DealID:    payload.Parent.DealID(),
+		FileID:    payload.FileID,
+		Details: map[string]string{
+			"parent":   payload.Parent.String(),
+			"filename": payload.Filename,
+			"edit":     fmt.Sprint(config.Document.Permissions.Edit),
+		},
+	})
+
 	*res = config
 	return nil
 }
@@ -0,0 +1,215 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	convertPollInterval = 1 * time.Second
+	pdfExtension        = "pdf"
+)
+
+func conversionKey(fid, outputType string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte("convert"+outputType+fid)))[:20]
+}
+
+func (c ConfigHandler) convert(ctx context.Context, settings response.DocSettingsResponse, payload request.ConvertRequest) (response.ConvertResponse, error) {
+	ticker := time.NewTicker(convertPollInterval)
+	defer ticker.Stop()
+
+	for {
+		payload.ExpiresAt = jwt.NewNumericDate(time.Now().Add(5 * time.Minute))
+		resp, err := c.convertClient.Convert(ctx, settings.DocAddress, settings.DocSecret, settings.DocHeader, payload)
+		if err != nil {
+			return resp, err
+		}
+
+		if resp.EndConvert {
+			return resp, nil
+		}
+
+		c.logger.Debugf("document %s conversion progress: %d%%", payload.Key, resp.Percent)
+		select {
+		case <-ctx.Done():
+			return resp, ErrOperationTimeout
+		case <-ticker.C:
+		}
+	}
+}
+
+// convertAndAttach converts a file to outputType and attaches the result
+// to the same parent entity as a new file.
+func (c ConfigHandler) convertAndAttach(
+	ctx context.Context,
+	req request.ConvertFileRequest,
+	outputType string,
+) (response.AddFileResponse, string, model.Token, error) {
+	var file response.AddFileResponse
+	if strings.TrimSpace(req.FileID) == "" || req.Parent.Validate() != nil {
+		return file, "", model.Token{}, ErrEmptyIdValue
+	}
+
+	uid := shared.NewUserIdentity(req.UID, req.CID).String()
+	var ures response.UserResponse
+	if err := c.client.Call(ctx, c.client.NewRequest(
+		fmt.Sprintf("%s:auth", c.config.Namespace), "UserSelectHandler.GetUser", uid,
+	), &ures); err != nil {
+		c.logger.Debugf("could not get user %s access info: %s", uid, err.Error())
+		return file, "", model.Token{}, err
+	}
+
+	token := model.Token{
+		AccessToken:  ures.AccessToken,
+		RefreshToken: ures.RefreshToken,
+		TokenType:    ures.TokenType,
+		Scope:        ures.Scope,
+		ApiDomain:    ures.ApiDomain,
+	}
+
+	settings, err := c.getSettings(ctx, req.CID)
+	if err != nil {
+		return file, "", token, err
+	}
+
+	location, err := c.getDownloadURL(ctx, ures, req.FileID)
+	if err != nil {
+		return file, "", token, err
+	}
+
+	if location == "" {
+		return file, "", token, ErrUnauthorizedAccess
+	}
+
+	escaped := c.formatManager.EscapeFileName(req.Filename)
+	result, err := c.convert(ctx, settings, request.ConvertRequest{
+		Async:      true,
+		FileType:   strings.ToLower(strings.ReplaceAll(filepath.Ext(req.Filename), ".", "")),
+		Key:        conversionKey(req.FileID, outputType),
+		OutputType: outputType,
+		Title:      escaped,
+		URL:        location,
+	})
+	if err != nil {
+		c.logger.Errorf("could not convert file %s to %s: %s", req.FileID, outputType, err.Error())
+		return file, "", token, err
+	}
+
+	filename := fmt.Sprintf("%s.%s", strings.TrimSuffix(escaped, filepath.Ext(escaped)), outputType)
+	file, err = c.apiClient.CreateFileFromURL(ctx, result.FileURL, req.Parent, filename, c.onlyoffice.Onlyoffice.Callback.MaxSize, token)
+	if err != nil {
+		c.logger.Errorf("could not upload converted file %s: %s", filename, err.Error())
+		return file, "", token, err
+	}
+
+	return file, filename, token, nil
+}
+
+func (c ConfigHandler) ConvertFile(ctx context.Context, req request.ConvertFileRequest, res *response.ConvertFileResponse) error {
+	c.logger.Debugf("processing a file conversion: %s", req.Filename)
+
+	ext := strings.ToLower(strings.ReplaceAll(filepath.Ext(req.Filename), ".", ""))
+	format, exists := c.formatManager.GetFormatByName(ext)
+	if !exists || !format.IsAutoConvertable() || !format.IsOpenXMLConvertable() {
+		return ErrUnsupportedConversion
+	}
+
+	file, filename, _, err := c.convertAndAttach(ctx, req, format.GetOpenXMLExtension())
+	if err != nil {
+		return err
+	}
+
+	c.audit.Emit(request.AuditEvent{
+		Type:      request.AuditFileConverted,
+		CompanyID: fmt.Sprint(req.CID),
+		UserID:    fmt.Sprint(req.UID),
+		DealID:    req.Parent.DealID(),
+		FileID:    fmt.Sprint(file.Data.ID),
+		Details: map[string]string{
+			"parent":    req.Parent.String(),
+			"source_id": req.FileID,
+			"filename":  filename,
+		},
+	})
+
+	*res = response.ConvertFileResponse{
+		FileID:   fmt.Sprint(file.Data.ID),
+		Filename: filename,
+	}
+
+	return nil
+}
+
+func (c ConfigHandler) ExportFile(ctx context.Context, req request.ExportFileRequest, res *response.ConvertFileResponse) error {
+	c.logger.Debugf("processing a pdf export: %s", req.Filename)
+
+	ext := strings.ToLower(strings.ReplaceAll(filepath.Ext(req.Filename), ".", ""))
+	format, exists := c.formatManager.GetFormatByName(ext)
+	if !exists || ext == pdfExtension || !format.IsConvertableTo(pdfExtension) {
+		return ErrUnsupportedConversion
+	}
+
+	file, filename, token, err := c.convertAndAttach(ctx, req.ConvertFileRequest, pdfExtension)
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	if req.Replace {
+		if err := c.apiClient.DeleteFile(ctx, req.FileID, token); err != nil {
+			c.logger.Errorf("could not remove original file %s after pdf export: %s", req.FileID, err.Error())
+		} else {
+			replaced = true
+		}
+	}
+
+	c.audit.Emit(request.AuditEvent{
+		Type:      request.AuditFileExported,
+		CompanyID: fmt.Sprint(req.CID),
+		UserID:    fmt.Sprint(req.UID),
+		DealID:    req.Parent.DealID(),
+		FileID:    fmt.Sprint(file.Data.ID),
+		Details: map[string]string{
+			"parent":    req.Parent.String(),
+			"source_id": req.FileID,
+			"filename":  filename,
+			"replaced":  fmt.Sprint(replaced),
+		},
+	})
+
+	*res = response.ConvertFileResponse{
+		FileID:   fmt.Sprint(file.Data.ID),
+		Filename: filename,
+		Replaced: replaced,
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDownloadURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/1/download":
+			http.Redirect(rw, r, "https://storage.pipedrive.com/1", http.StatusFound)
+		case "/files/2/download":
+			rw.WriteHeader(http.StatusNotFound)
+		default:
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	handler := ConfigHandler{}
+	user := response.UserResponse{ApiDomain: server.URL, AccessToken: "mock"}
+
+	t.Run("get a download url", func(t *testing.T) {
+		location, err := handler.getDownloadURL(context.Background(), user, "1")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://storage.pipedrive.com/1", location)
+	})
+
+	t.Run("get a removed file url", func(t *testing.T) {
+		_, err := handler.getDownloadURL(context.Background(), user, "2")
+		assert.ErrorIs(t, err, ErrFileUnavailable)
+	})
+
+	t.Run("get a url on a pipedrive failure", func(t *testing.T) {
+		_, err := handler.getDownloadURL(context.Background(), user, "3")
+		var serr *pclient.UnexpectedStatusCodeError
+		assert.ErrorAs(t, err, &serr)
+	})
+}
@@ -21,9 +21,14 @@ package handler
 import "errors"
 
 var (
-	ErrInvalidContextValue = errors.New("could not extract context value")
-	ErrEmptyIdValue        = errors.New("could not perform current action with an empty id")
-	ErrUnauthorizedAccess  = errors.New("unauthorized file access")
-	ErrNoSettingsFound     = errors.New("could not find document server settings")
-	ErrOperationTimeout    = errors.New("operation timeout")
+	ErrInvalidContextValue   = errors.New("could not extract context value")
+	ErrEmptyIdValue          = errors.New("could not perform current action with an empty id")
+	ErrUnauthorizedAccess    = errors.New("unauthorized file access")
+	ErrNoSettingsFound       = errors.New("could not find document server settings")
+	ErrOperationTimeout      = errors.New("operation timeout")
+	ErrUnknownVersion        = errors.New("could not find requested file version")
+	ErrUnsupportedConversion = errors.New("unsupported conversion format")
+	ErrNoActiveSession       = errors.New("no active editing session")
+	ErrFileUnavailable       = errors.New("file is no longer available")
+	ErrNoSessionUsers        = errors.New("no users to disconnect")
 )
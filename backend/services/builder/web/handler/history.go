@@ -0,0 +1,172 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func revisionKey(fid string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(fid)))[:20]
+}
+
+func (c ConfigHandler) getRevisions(ctx context.Context, req request.BuildHistoryRequest) ([]domain.Revision, error) {
+	revisions, err := c.revisionService.GetRevisions(ctx, req.FileID)
+	if err != nil {
+		c.logger.Debugf("could not get file %s revisions: %s", req.FileID, err.Error())
+		return nil, err
+	}
+
+	for _, revision := range revisions {
+		if revision.CompanyID != fmt.Sprint(req.CID) {
+			c.logger.Warnf("company %d tried to access file %s history", req.CID, req.FileID)
+			return nil, ErrUnauthorizedAccess
+		}
+	}
+
+	return revisions, nil
+}
+
+func (c ConfigHandler) BuildHistory(ctx context.Context, req request.BuildHistoryRequest, res *response.BuildHistoryResponse) error {
+	c.logger.Debugf("processing file %s history", req.FileID)
+
+	revisions, err := c.getRevisions(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if len(revisions) < 1 {
+		*res = response.BuildHistoryResponse{
+			CurrentVersion: 1,
+			History: []response.HistoryEntry{
+				{Key: req.DocKey, Version: 1},
+			},
+		}
+		return nil
+	}
+
+	history := []response.HistoryEntry{
+		{Key: revisionKey(revisions[0].DocumentID), Version: 1},
+	}
+
+	for idx, revision := range revisions {
+		key := revisionKey(revision.FileID)
+		if idx == len(revisions)-1 {
+			key = req.DocKey
+		}
+
+		history = append(history, response.HistoryEntry{
+			Created: revision.CreatedAt.UTC().Format("2006-01-02 15:04:05"),
+			Key:     key,
+			User: &response.User{
+				ID:   revision.UserID,
+				Name: revision.UserName,
+			},
+			Version: revision.Version,
+		})
+	}
+
+	*res = response.BuildHistoryResponse{
+		CurrentVersion: revisions[len(revisions)-1].Version,
+		History:        history,
+	}
+
+	return nil
+}
+
+func (c ConfigHandler) BuildHistoryData(ctx context.Context, req request.BuildHistoryRequest, res *response.BuildHistoryDataResponse) error {
+	c.logger.Debugf("processing file %s history data (version %d)", req.FileID, req.Version)
+
+	revisions, err := c.getRevisions(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	fid, key := req.FileID, req.DocKey
+	if len(revisions) > 0 {
+		fid = revisions[0].DocumentID
+		key = revisionKey(fid)
+		for idx, revision := range revisions {
+			if revision.Version == req.Version {
+				fid = revision.FileID
+				key = revisionKey(fid)
+				if idx == len(revisions)-1 {
+					key = req.DocKey
+				}
+			}
+		}
+
+		if req.Version < 1 || req.Version > revisions[len(revisions)-1].Version {
+			return ErrUnknownVersion
+		}
+	} else if req.Version != 1 {
+		return ErrUnknownVersion
+	}
+
+	var ures response.UserResponse
+	if err := c.client.Call(ctx, c.client.NewRequest(
+		fmt.Sprintf("%s:auth", c.config.Namespace), "UserSelectHandler.GetUser",
+		shared.NewUserIdentity(req.UID, req.CID).String(),
+	), &ures); err != nil {
+		c.logger.Debugf("could not get user %s access info: %s", shared.NewUserIdentity(req.UID, req.CID).String(), err.Error())
+		return err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	settings, err := c.getSettings(tctx, req.CID)
+	if err != nil {
+		return err
+	}
+
+	location, err := c.getDownloadURL(tctx, ures, fid)
+	if err != nil {
+		return err
+	}
+
+	data := response.BuildHistoryDataResponse{
+		FileType: strings.ToLower(strings.ReplaceAll(filepath.Ext(req.Filename), ".", "")),
+		Key:      key,
+		URL:      location,
+		Version:  req.Version,
+	}
+
+	data.ExpiresAt = jwt.NewNumericDate(time.Now().Add(5 * time.Minute))
+	token, err := c.jwtManager.Sign(settings.DocSecret, data)
+	if err != nil {
+		c.logger.Debugf("could not sign document server history data: %s", err.Error())
+		return err
+	}
+
+	data.Token = token
+	*res = data
+	return nil
+}
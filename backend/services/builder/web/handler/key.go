@@ -0,0 +1,52 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+
+	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/port"
+)
+
+type DocumentKeyHandler struct {
+	service port.DocumentKeyService
+	logger  plog.Logger
+}
+
+func NewDocumentKeyHandler(
+	service port.DocumentKeyService,
+	logger plog.Logger,
+) DocumentKeyHandler {
+	return DocumentKeyHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RotateKey retires a document key once its editing session has been closed,
+// so that the key is never handed out again.
+func (h DocumentKeyHandler) RotateKey(ctx context.Context, key *string, res *interface{}) error {
+	if err := h.service.RotateKey(ctx, *key); err != nil {
+		h.logger.Errorf("could not rotate document key %s: %s", *key, err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+)
+
+var modeRanks = map[string]int{
+	"view":    1,
+	"comment": 2,
+	"review":  3,
+	"edit":    4,
+}
+
+// normalizeMode keeps the default edit mode for roles left unset and turns
+// modes this release does not know into view, so that a mistyped policy never
+// grants more than it meant to.
+func normalizeMode(mode string) string {
+	if mode == "" {
+		return "edit"
+	}
+
+	if _, ok := modeRanks[mode]; !ok {
+		return "view"
+	}
+
+	return mode
+}
+
+func rankMode(mode string) int {
+	return modeRanks[normalizeMode(mode)]
+}
+
+// resolveMode picks the most permissive editor mode granted by the user's
+// Pipedrive access entries. Admins of the company or of the sales app get
+// the admin mode, mapped permission sets get their configured mode and
+// everyone else falls back to the regular mode.
+func resolveMode(access []model.Access, roles response.DocRolesResponse) string {
+	mode, matched := "", false
+	grant := func(candidate string) {
+		candidate = normalizeMode(candidate)
+		if !matched || rankMode(candidate) > rankMode(mode) {
+			mode = candidate
+		}
+		matched = true
+	}
+
+	for _, entry := range access {
+		if entry.Admin && (entry.App == "global" || entry.App == "sales") {
+			grant(roles.Admin)
+			continue
+		}
+
+		if candidate, ok := roles.PermissionSets[entry.PermissionID]; ok && entry.PermissionID != "" {
+			grant(candidate)
+		}
+	}
+
+	if !matched {
+		mode = roles.Regular
+	}
+
+	return normalizeMode(mode)
+}
+
+func applyMode(permissions response.Permissions, mode, documentType string) response.Permissions {
+	switch mode {
+	case "review":
+		permissions.Review = (permissions.Edit || permissions.Review) && documentType == "word"
+		permissions.Edit = false
+	case "comment":
+		permissions.Edit = false
+		permissions.Review = false
+	case "view":
+		permissions.Edit = false
+		permissions.Review = false
+		permissions.Comment = false
+	}
+
+	return permissions
+}
@@ -0,0 +1,100 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveMode(t *testing.T) {
+	roles := response.DocRolesResponse{
+		Admin:   "edit",
+		Regular: "comment",
+		PermissionSets: map[string]string{
+			"readonly": "view",
+			"editors":  "edit",
+		},
+	}
+
+	t.Run("admin gets admin mode", func(t *testing.T) {
+		assert.Equal(t, "edit", resolveMode([]model.Access{
+			{App: "global", Admin: true},
+		}, roles))
+	})
+
+	t.Run("mapped permission set", func(t *testing.T) {
+		assert.Equal(t, "view", resolveMode([]model.Access{
+			{App: "sales", PermissionID: "readonly"},
+		}, roles))
+	})
+
+	t.Run("most permissive permission set wins", func(t *testing.T) {
+		assert.Equal(t, "edit", resolveMode([]model.Access{
+			{App: "sales", PermissionID: "readonly"},
+			{App: "projects", PermissionID: "editors"},
+		}, roles))
+	})
+
+	t.Run("regular user fallback", func(t *testing.T) {
+		assert.Equal(t, "comment", resolveMode([]model.Access{
+			{App: "sales", PermissionID: "unknown"},
+		}, roles))
+	})
+
+	t.Run("empty roles default to edit", func(t *testing.T) {
+		assert.Equal(t, "edit", resolveMode(nil, response.DocRolesResponse{}))
+	})
+
+	t.Run("unknown modes default to view", func(t *testing.T) {
+		assert.Equal(t, "view", resolveMode(nil, response.DocRolesResponse{Regular: "edti"}))
+		assert.Equal(t, "comment", resolveMode([]model.Access{
+			{App: "sales", PermissionID: "typo"},
+			{App: "projects", PermissionID: "commenters"},
+		}, response.DocRolesResponse{
+			PermissionSets: map[string]string{"typo": "owner", "commenters": "comment"},
+		}))
+	})
+}
+
+func TestApplyMode(t *testing.T) {
+	full := response.Permissions{Edit: true, Comment: true}
+
+	t.Run("review mode for documents", func(t *testing.T) {
+		p := applyMode(full, "review", "word")
+		assert.False(t, p.Edit)
+		assert.True(t, p.Review)
+	})
+
+	t.Run("review mode for spreadsheets", func(t *testing.T) {
+		p := applyMode(full, "review", "cell")
+		assert.False(t, p.Edit)
+		assert.False(t, p.Review)
+		assert.True(t, p.Comment)
+	})
+
+	t.Run("view mode", func(t *testing.T) {
+		p := applyMode(full, "view", "word")
+		assert.False(t, p.Edit)
+		assert.False(t, p.Comment)
+	})
+}
@@ -0,0 +1,85 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+
+	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+)
+
+type RevisionInsertHandler struct {
+	service    port.RevisionService
+	keyService port.DocumentKeyService
+	logger     plog.Logger
+}
+
+func NewRevisionInsertHandler(
+	service port.RevisionService,
+	keyService port.DocumentKeyService,
+	logger plog.Logger,
+) RevisionInsertHandler {
+	return RevisionInsertHandler{
+		service:    service,
+		keyService: keyService,
+		logger:     logger,
+	}
+}
+
+func (r RevisionInsertHandler) InsertRevision(ctx context.Context, req request.RevisionRequest, res *response.RevisionResponse) error {
+	revision, err := r.service.CreateRevision(ctx, domain.Revision{
+		FileID:       req.FileID,
+		PreviousID:   req.PreviousID,
+		Key:          req.Key,
+		CompanyID:    req.CompanyID,
+		DealID:       req.DealID,
+		UserID:       req.UserID,
+		UserName:     req.UserName,
+		Size:         req.Size,
+		Checksum:     req.Checksum,
+		Forcesave:    req.Forcesave,
+		Contributors: req.Contributors,
+	})
+	if err != nil {
+		r.logger.Errorf("could not persist file %s revision: %s", req.FileID, err.Error())
+		return err
+	}
+
+	r.logger.Debugf("persisted revision %d of document %s", revision.Version, revision.DocumentID)
+	// A force save keeps the session open, so the new file joins it. Keys of
+	// closed sessions are rotated by the callback through DocumentKeyHandler.
+	if req.Forcesave {
+		if _, err := r.keyService.BindKey(ctx, req.CompanyID, revision.FileID, req.Key); err != nil {
+			r.logger.Errorf("could not bind document key to file %s: %s", revision.FileID, err.Error())
+		}
+	}
+
+	*res = response.RevisionResponse{
+		DocumentID: revision.DocumentID,
+		FileID:     revision.FileID,
+		PreviousID: revision.PreviousID,
+		Version:    revision.Version,
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/builder/web/core/adapter"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+)
+
+// resolveSession finds the document server and the key a file is being edited with.
+func (c ConfigHandler) resolveSession(ctx context.Context, req request.SessionRequest) (response.DocSettingsResponse, string, error) {
+	if strings.TrimSpace(req.FileID) == "" {
+		return response.DocSettingsResponse{}, "", ErrEmptyIdValue
+	}
+
+	key, err := c.keyService.GetKey(ctx, fmt.Sprint(req.CID), req.FileID)
+	if err != nil {
+		if errors.Is(err, adapter.ErrNoDocumentKey) {
+			return response.DocSettingsResponse{}, "", ErrNoActiveSession
+		}
+
+		return response.DocSettingsResponse{}, "", err
+	}
+
+	settings, err := c.getSettings(ctx, req.CID)
+	if err != nil {
+		return settings, "", err
+	}
+
+	return settings, key.Key, nil
+}
+
+// GetSession reports whether the document server still holds the file's editing
+// session. The info command posts connected users to the callback url instead of
+// returning them, so none are listed here.
+func (c ConfigHandler) GetSession(ctx context.Context, req request.SessionRequest, res *response.SessionResponse) error {
+	settings, key, err := c.resolveSession(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.commandClient.Info(ctx, settings.DocAddress, settings.DocSecret, settings.DocHeader, key); err != nil {
+		if pclient.IsCommandError(err, pclient.CommandKeyNotFound) {
+			return ErrNoActiveSession
+		}
+
+		c.logger.Errorf("could not get file %s session info: %s", req.FileID, err.Error())
+		return err
+	}
+
+	*res = response.SessionResponse{Key: key}
+	return nil
+}
+
+func (c ConfigHandler) ForceSaveSession(ctx context.Context, req request.SessionRequest, res *response.SessionResponse) error {
+	settings, key, err := c.resolveSession(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	uid := shared.NewUserIdentity(req.UID, req.CID).String()
+	*res = response.SessionResponse{Key: key, Saved: true}
+	if _, err := c.commandClient.ForceSave(ctx, settings.DocAddress, settings.DocSecret, settings.DocHeader, key, uid); err != nil {
+		switch {
+		case pclient.IsCommandError(err, pclient.CommandKeyNotFound):
+			return ErrNoActiveSession
+		case pclient.IsCommandError(err, pclient.CommandNoChanges):
+			c.logger.Debugf("file %s has no changes to force save", req.FileID)
+			res.Saved = false
+			return nil
+		default:
+			c.logger.Errorf("could not force save file %s: %s", req.FileID, err.Error())
+			return err
+		}
+	}
+
+	c.audit.Emit(request.AuditEvent{
+		Type:      request.AuditSessionForceSaved,
+		CompanyID: fmt.Sprint(req.CID),
+		UserID:    fmt.Sprint(req.UID),
+		FileID:    req.FileID,
+		Details: map[string]string{
+			"key": key,
+		},
+	})
+
+	return nil
+}
+
+// DisconnectSession drops the given users from the file's editing session.
+func (c ConfigHandler) DisconnectSession(ctx context.Context, req request.SessionRequest, res *response.SessionResponse) error {
+	users := req.Users
+	if len(users) == 0 {
+		return ErrNoSessionUsers
+	}
+
+	settings, key, err := c.resolveSession(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	*res = response.SessionResponse{Key: key, Users: users}
+	if _, err := c.commandClient.Drop(ctx, settings.DocAddress, settings.DocSecret, settings.DocHeader, key, users); err != nil {
+		if pclient.IsCommandError(err, pclient.CommandKeyNotFound) {
+			return ErrNoActiveSession
+		}
+
+		c.logger.Errorf("could not disconnect file %s users: %s", req.FileID, err.Error())
+		return err
+	}
+
+	c.audit.Emit(request.AuditEvent{
+		Type:      request.AuditSessionDisconnected,
+		CompanyID: fmt.Sprint(req.CID),
+		UserID:    fmt.Sprint(req.UID),
+		FileID:    req.FileID,
+		Details: map[string]string{
+			"key":   key,
+			"users": strings.Join(users, ","),
+		},
+	})
+
+	return nil
+}
@@ -24,14 +24,20 @@ import (
 )
 
 type ConfigRPCServer struct {
-	configHandler handler.ConfigHandler
+	configHandler   handler.ConfigHandler
+	revisionHandler handler.RevisionInsertHandler
+	keyHandler      handler.DocumentKeyHandler
 }
 
 func NewConfigRPCServer(
 	configHandler handler.ConfigHandler,
+	revisionHandler handler.RevisionInsertHandler,
+	keyHandler handler.DocumentKeyHandler,
 ) rpc.RPCEngine {
 	return ConfigRPCServer{
-		configHandler: configHandler,
+		configHandler:   configHandler,
+		revisionHandler: revisionHandler,
+		keyHandler:      keyHandler,
 	}
 }
 
@@ -40,5 +46,5 @@ func (a ConfigRPCServer) BuildMessageHandlers() []rpc.RPCMessageHandler {
 }
 
 func (a ConfigRPCServer) BuildHandlers() []interface{} {
-	return []interface{}{a.configHandler}
+	return []interface{}{a.configHandler, a.revisionHandler, a.keyHandler}
 }
@@ -0,0 +1,144 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pkg "github.com/ONLYOFFICE/onlyoffice-integration-adapters"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/adapter"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/service"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/urfave/cli/v2"
+)
+
+func Queue() *cli.Command {
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:    "config_path",
+			Usage:   "sets custom configuration path",
+			Aliases: []string{"config", "conf", "c"},
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "sets the command timeout",
+			Value: 1 * time.Minute,
+		},
+	}
+
+	return &cli.Command{
+		Name:     "queue",
+		Usage:    "inspects and replays dead save jobs",
+		Category: "maintenance",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "dead",
+				Usage: "lists save jobs that have run out of attempts",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "company",
+						Usage: "lists dead jobs of a single company",
+					},
+				}, flags...),
+				Action: func(c *cli.Context) error {
+					var (
+						CONFIG_PATH = c.String("config_path")
+						COMPANY     = c.String("company")
+						TIMEOUT     = c.Duration("timeout")
+					)
+
+					app := pkg.NewBootstrapper(CONFIG_PATH, pkg.WithModules(
+						shared.BuildNewSaveQueueConfig(CONFIG_PATH),
+						adapter.BuildNewSaveQueueAdapter,
+						service.NewSaveQueueService,
+					), pkg.WithInvokables(func(queue port.SaveQueueService, logger log.Logger) error {
+						ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+						defer cancel()
+
+						jobs, err := queue.GetDeadJobs(ctx, COMPANY)
+						if err != nil {
+							return err
+						}
+
+						for _, job := range jobs {
+							logger.Infof(
+								"dead save job %s. Document: %s, company: %s, file: %s, attempts: %d, reason: %s",
+								job.ID, job.Key, job.CompanyID, job.FileID, job.Attempts, job.LastError,
+							)
+						}
+
+						logger.Infof("found %d dead save jobs", len(jobs))
+						return nil
+					})).Bootstrap()
+
+					return app.Err()
+				},
+			},
+			{
+				Name:  "replay",
+				Usage: "moves dead save jobs back to the queue",
+				Flags: append([]cli.Flag{
+					&cli.StringSliceFlag{
+						Name:     "id",
+						Usage:    "sets dead job ids to replay",
+						Required: true,
+					},
+				}, flags...),
+				Action: func(c *cli.Context) error {
+					var (
+						CONFIG_PATH = c.String("config_path")
+						IDS         = c.StringSlice("id")
+						TIMEOUT     = c.Duration("timeout")
+					)
+
+					app := pkg.NewBootstrapper(CONFIG_PATH, pkg.WithModules(
+						shared.BuildNewSaveQueueConfig(CONFIG_PATH),
+						adapter.BuildNewSaveQueueAdapter,
+						service.NewSaveQueueService,
+					), pkg.WithInvokables(func(queue port.SaveQueueService, logger log.Logger) error {
+						ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+						defer cancel()
+
+						var failed []string
+						for _, id := range IDS {
+							if _, err := queue.Replay(ctx, id); err != nil {
+								logger.Errorf("could not replay dead save job %s. Reason: %s", id, err.Error())
+								failed = append(failed, id)
+							}
+						}
+
+						if len(failed) > 0 {
+							return fmt.Errorf("could not replay save jobs: %s", strings.Join(failed, ", "))
+						}
+
+						logger.Infof("replayed %d dead save jobs", len(IDS))
+						return nil
+					})).Bootstrap()
+
+					return app.Err()
+				},
+			},
+		},
+	}
+}
@@ -23,7 +23,10 @@ import (
 	chttp "github.com/ONLYOFFICE/onlyoffice-integration-adapters/service/http"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/controller"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/adapter"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/service"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
 	"github.com/urfave/cli/v2"
 )
@@ -49,8 +52,16 @@ func Server() *cli.Command {
 				chttp.NewService, web.NewServer,
 				controller.NewCallbackController,
 				shared.BuildNewOnlyofficeConfig(CONFIG_PATH),
+				shared.BuildNewCallbackStateConfig(CONFIG_PATH),
+				shared.BuildNewSaveQueueConfig(CONFIG_PATH),
+				shared.BuildNewDistributedCacheConfig(CONFIG_PATH),
+				cache.NewCache,
+				shared.NewAuditEmitter,
 				client.NewPipedriveApiClient,
-			)).Bootstrap()
+				adapter.BuildNewSaveQueueAdapter,
+				service.NewSaveQueueService,
+				controller.NewSaveWorker,
+			), pkg.WithInvokables(controller.RunSaveWorker)).Bootstrap()
 
 			if err := app.Err(); err != nil {
 				return err
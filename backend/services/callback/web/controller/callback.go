@@ -23,13 +23,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
 	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/port"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
 	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
@@ -38,33 +42,74 @@ import (
 	"go-micro.dev/v4/util/backoff"
 )
 
+// Document servers send tokens in the Authorization header unless configured otherwise.
+const defaultJwtHeader = "Authorization"
+
 type CallbackController struct {
 	client       client.Client
+	cache        cache.Cache
 	pipedriveAPI pclient.PipedriveApiClient
 	jwtManager   crypto.JwtManager
 	config       *config.ServerConfig
 	onlyoffice   *shared.OnlyofficeConfig
+	state        *shared.CallbackStateConfig
+	audit        shared.AuditEmitter
+	queue        port.SaveQueueService
 	logger       plog.Logger
 }
 
 func NewCallbackController(
 	client client.Client,
+	cache cache.Cache,
 	pipedriveAPI pclient.PipedriveApiClient,
 	jwtManager crypto.JwtManager,
 	config *config.ServerConfig,
 	onlyoffice *shared.OnlyofficeConfig,
+	state *shared.CallbackStateConfig,
+	audit shared.AuditEmitter,
+	queue port.SaveQueueService,
 	logger plog.Logger,
 ) *CallbackController {
 	return &CallbackController{
 		client:       client,
+		cache:        cache,
 		pipedriveAPI: pipedriveAPI,
 		jwtManager:   jwtManager,
 		config:       config,
 		onlyoffice:   onlyoffice,
+		state:        state,
+		audit:        audit,
+		queue:        queue,
 		logger:       logger,
 	}
 }
 
+// verifyToken verifies the callback body token or, when the document server is configured
+// to send tokens in a header only, the token from the company's JWT header.
+func (c CallbackController) verifyToken(r *http.Request, secret, header string, body *request.CallbackRequest) error {
+	if body.Token != "" {
+		return c.jwtManager.Verify(secret, body.Token, body)
+	}
+
+	if header == "" {
+		header = defaultJwtHeader
+	}
+
+	token := pclient.ExtractHeaderToken(r.Header.Get(header))
+	if token == "" {
+		return ErrMissingCallbackToken
+	}
+
+	var claims request.CallbackHeaderClaims
+	if err := c.jwtManager.Verify(secret, token, &claims); err != nil {
+		return err
+	}
+
+	*body = claims.Payload
+	body.Token = token
+	return nil
+}
+
 func (c CallbackController) isDemoModeValid(settings response.DocSettingsResponse) bool {
 	if !settings.DemoEnabled {
 		return false
@@ -80,8 +125,6 @@ func (c CallbackController) isDemoModeValid(settings response.DocSettingsRespons
 
 func (c CallbackController) BuildPostHandleCallback() http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
-		query := r.URL.Query()
-		cid, did, fid := strings.TrimSpace(query.Get("cid")), strings.TrimSpace(query.Get("did")), strings.TrimSpace(query.Get("fid"))
 		rw.Header().Set("Content-Type", "application/json")
 
 		var body request.CallbackRequest
@@ -94,17 +137,19 @@ func (c CallbackController) BuildPostHandleCallback() http.HandlerFunc {
 			return
 		}
 
-		if body.Token == "" {
-			c.logger.Error("invalid callback body token")
-			rw.WriteHeader(http.StatusBadRequest)
+		state, err := shared.VerifyCallbackState(c.jwtManager, c.state.State.Secret, r.URL.Query().Get("state"))
+		if err != nil {
+			c.logger.Errorf("invalid callback state. Reason: %s", err.Error())
+			rw.WriteHeader(http.StatusForbidden)
 			rw.Write(response.CallbackResponse{
 				Error: 1,
 			}.ToJSON())
 			return
 		}
 
-		if cid == "" || did == "" || fid == "" {
-			c.logger.Error("invalid query parameter")
+		parent, err := model.ParseParent(state.Parent)
+		if err != nil {
+			c.logger.Errorf("invalid callback state parent. Reason: %s", err.Error())
 			rw.WriteHeader(http.StatusBadRequest)
 			rw.Write(response.CallbackResponse{
 				Error: 1,
@@ -112,6 +157,8 @@ func (c CallbackController) BuildPostHandleCallback() http.HandlerFunc {
 			return
 		}
 
+		cid, fid, filename := fmt.Sprint(state.CompanyID), state.FileID, state.Filename
+
 		req := c.client.NewRequest(fmt.Sprintf("%s:settings", c.config.Namespace), "SettingsSelectHandler.GetSettings", cid)
 		var res response.DocSettingsResponse
 		if err := c.client.Call(r.Context(), req, &res); err != nil {
@@ -123,7 +170,7 @@ func (c CallbackController) BuildPostHandleCallback() http.HandlerFunc {
 			return
 		}
 
-		var jwtSecret string
+		var jwtSecret, jwtHeader string
 		if c.isDemoModeValid(res) {
 			if c.onlyoffice.Onlyoffice.Demo.DocumentServerSecret == "" {
 				c.logger.Errorf("demo mode is enabled but demo secret is not configured")
@@ -135,6 +182,7 @@ func (c CallbackController) BuildPostHandleCallback() http.HandlerFunc {
 			}
 
 			jwtSecret = c.onlyoffice.Onlyoffice.Demo.DocumentServerSecret
+			jwtHeader = c.onlyoffice.Onlyoffice.Demo.DocumentServerHeader
 		} else {
 			if res.DocSecret == "" {
 				c.logger.Errorf("no document server secret found and demo mode not valid (company %s)", cid)
@@ -146,10 +194,11 @@ func (c CallbackController) BuildPostHandleCallback() http.HandlerFunc {
 			}
 
 			jwtSecret = res.DocSecret
+			jwtHeader = res.DocHeader
 		}
 
-		if err := c.jwtManager.Verify(jwtSecret, body.Token, &body); err != nil {
-			c.logger.Errorf("could not verify callback jwt (%s). Reason: %s", body.Token, err.Error())
+		if err := c.verifyToken(r, jwtSecret, jwtHeader, &body); err != nil {
+			c.logger.Errorf("could not verify callback jwt. Reason: %s", err.Error())
 			rw.WriteHeader(http.StatusForbidden)
 			rw.Write(response.CallbackResponse{
 				Error: 1,
@@ -166,60 +215,69 @@ func (c CallbackController) BuildPostHandleCallback() http.HandlerFunc {
 			return
 		}
 
-		if body.Status == 2 {
-			filename := strings.TrimSpace(r.URL.Query().Get("filename"))
-			if filename == "" {
-				rw.WriteHeader(http.StatusInternalServerError)
-				c.logger.Errorf("callback request %s does not contain a filename", body.Key)
+		// A state issued for one document must not authorize saves into another.
+		if body.Key != state.Key {
+			c.logger.Errorf("callback key %s does not match its state", body.Key)
+			rw.WriteHeader(http.StatusForbidden)
+			rw.Write(response.CallbackResponse{
+				Error: 1,
+			}.ToJSON())
+			return
+		}
+
+		// Saving closes the editing session. Its key is rotated before the save
+		// is queued, so that the next session never reopens the closed document
+		// whether or not its revision gets recorded.
+		if body.Status == 2 || body.Status == 3 {
+			if err := c.rotateKey(r.Context(), body.Key); err != nil {
+				c.logger.Errorf("could not rotate document key %s: %s", body.Key, err.Error())
+				rw.WriteHeader(http.StatusBadRequest)
+				rw.Write(response.CallbackResponse{
+					Error: 1,
+				}.ToJSON())
+				return
+			}
+		}
+
+		session := c.trackSession(r.Context(), body)
+		switch body.Status {
+		case 1:
+			c.logger.Debugf("document %s is being edited", body.Key)
+		case 2, 6:
+			if err := c.enqueueSave(r.Context(), body, session, cid, parent, fid, filename); err != nil {
+				c.logger.Errorf("could not enqueue document %s save (status %d): %s", body.Key, body.Status, err.Error())
+				rw.WriteHeader(http.StatusBadRequest)
+				rw.Write(response.CallbackResponse{
+					Error: 1,
+				}.ToJSON())
+				return
+			}
+		case 3, 7:
+			c.logger.Errorf("document server could not save document %s (status %d, forcesave type %d)", body.Key, body.Status, body.ForceSaveType)
+			if body.URL == "" {
+				rw.WriteHeader(http.StatusBadRequest)
 				rw.Write(response.CallbackResponse{
 					Error: 1,
 				}.ToJSON())
 				return
 			}
 
-			ctx, cancel := context.WithTimeout(r.Context(), time.Duration(c.onlyoffice.Onlyoffice.Callback.UploadTimeout)*time.Second)
-			defer cancel()
-
-			usr := body.Users[0]
-			if usr != "" {
-				size, err := c.pipedriveAPI.ValidateFileSize(ctx, c.onlyoffice.Onlyoffice.Callback.MaxSize, body.URL)
-				if err != nil {
-					c.logger.Errorf("could not validate file %s: %s", filename, err.Error())
-					rw.WriteHeader(http.StatusBadRequest)
-					rw.Write(response.CallbackResponse{
-						Error: 1,
-					}.ToJSON())
-					return
-				}
-
-				req := c.client.NewRequest(fmt.Sprintf("%s:auth", c.config.Namespace), "UserSelectHandler.GetUser", usr)
-				var ures response.UserResponse
-				if err := c.client.Call(ctx, req, &ures, client.WithRetries(3), client.WithBackoff(func(ctx context.Context, req client.Request, attempts int) (time.Duration, error) {
-					return backoff.Do(attempts), nil
-				})); err != nil {
-					c.logger.Errorf("could not get user tokens: %s", err.Error())
-					rw.WriteHeader(http.StatusBadRequest)
-					rw.Write(response.CallbackResponse{
-						Error: 1,
-					}.ToJSON())
-					return
-				}
-
-				if err := c.pipedriveAPI.UploadFile(ctx, body.URL, did, fid, filename, size, model.Token{
-					AccessToken:  ures.AccessToken,
-					RefreshToken: ures.RefreshToken,
-					TokenType:    ures.TokenType,
-					Scope:        ures.Scope,
-					ApiDomain:    ures.ApiDomain,
-				}); err != nil {
-					c.logger.Debugf("could not upload an onlyoffice file to pipedrive: %s", err.Error())
-					rw.WriteHeader(http.StatusBadRequest)
-					rw.Write(response.CallbackResponse{
-						Error: 1,
-					}.ToJSON())
-					return
-				}
+			if err := c.enqueueSave(r.Context(), body, session, cid, parent, fid, filename); err != nil {
+				c.logger.Errorf("could not enqueue document %s recovery after a saving error: %s", body.Key, err.Error())
+				rw.WriteHeader(http.StatusBadRequest)
+				rw.Write(response.CallbackResponse{
+					Error: 1,
+				}.ToJSON())
+				return
 			}
+
+			c.logger.Warnf("document %s will be recovered from the last known version", body.Key)
+		case 4:
+			c.logger.Debugf("document %s has been closed with no changes", body.Key)
+		}
+
+		if body.Status == 2 || body.Status == 3 || body.Status == 4 {
+			c.closeSession(r.Context(), body.Key)
 		}
 
 		rw.WriteHeader(http.StatusOK)
@@ -228,3 +286,230 @@ func (c CallbackController) BuildPostHandleCallback() http.HandlerFunc {
 		}.ToJSON())
 	}
 }
+
+// enqueueSave persists a save job so that the document server is acknowledged
+// right away while the upload is retried in the background.
+func (c CallbackController) enqueueSave(
+	ctx context.Context, body request.CallbackRequest, session editingSession,
+	cid string, parent model.Parent, fid, filename string,
+) error {
+	if filename == "" {
+		return ErrEmptyFilename
+	}
+
+	candidates := session.Candidates(body)
+	if len(candidates) < 1 {
+		c.logger.Warnf("callback request %s does not contain any users. Skipping upload", body.Key)
+		return nil
+	}
+
+	job, err := c.queue.Enqueue(ctx, domain.SaveJob{
+		Key:          body.Key,
+		Status:       body.Status,
+		URL:          body.URL,
+		CompanyID:    cid,
+		Parent:       parent.String(),
+		FileID:       fid,
+		Filename:     filename,
+		Candidates:   candidates,
+		Contributors: session.Contributors(body),
+	})
+	if err != nil {
+		return err
+	}
+
+	c.logger.Debugf("document %s save has been queued as job %s", body.Key, job.ID)
+	return nil
+}
+
+// saveFile uploads a queued document save to pipedrive. Failures are returned
+// with their context and logged once by the save worker.
+func (c CallbackController) saveFile(ctx context.Context, job domain.SaveJob) error {
+	if job.Filename == "" {
+		return ErrEmptyFilename
+	}
+
+	parent, err := model.ParseParent(job.Parent)
+	if err != nil {
+		return err
+	}
+
+	cid, fid, filename := job.CompanyID, job.FileID, job.Filename
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.onlyoffice.Onlyoffice.Callback.UploadTimeout)*time.Second)
+	defer cancel()
+
+	transfer, err := c.pipedriveAPI.DownloadFile(ctx, job.URL, c.onlyoffice.Onlyoffice.Callback.MaxSize)
+	if err != nil {
+		return fmt.Errorf("could not download file %s: %w", filename, err)
+	}
+	defer transfer.Close()
+
+	uid, ures, err := c.resolveUploader(ctx, cid, job.Candidates)
+	if err != nil {
+		return fmt.Errorf("could not get user tokens: %w", err)
+	}
+
+	token := model.Token{
+		AccessToken:  ures.AccessToken,
+		RefreshToken: ures.RefreshToken,
+		TokenType:    ures.TokenType,
+		Scope:        ures.Scope,
+		ApiDomain:    ures.ApiDomain,
+	}
+
+	file, err := c.pipedriveAPI.UploadFile(ctx, transfer, parent, filename, token)
+	if err != nil {
+		return fmt.Errorf("could not upload file %s to pipedrive: %w", filename, err)
+	}
+
+	uploader := uid
+	usr, err := c.pipedriveAPI.GetMe(ctx, token)
+	if err != nil {
+		c.logger.Warnf("could not get uploader %s info: %s", uid, err.Error())
+	} else {
+		uploader = fmt.Sprint(usr.ID)
+	}
+
+	// A replayed save may be older than files uploaded since it died. It is
+	// kept as a standalone copy rather than becoming the document's latest
+	// revision and retiring a newer file.
+	previous := fid
+	if job.Replayed {
+		c.logger.Warnf("uploaded replayed save of document %s as file %d without superseding file %s", job.Key, file.Data.ID, fid)
+	} else {
+		previous = c.recordRevision(ctx, job.Key, request.RevisionRequest{
+			Key:          job.Key,
+			FileID:       fmt.Sprint(file.Data.ID),
+			PreviousID:   fid,
+			CompanyID:    cid,
+			DealID:       parent.DealID(),
+			UserID:       uid,
+			UserName:     usr.Name,
+			Size:         transfer.Size,
+			Checksum:     transfer.Checksum,
+			Forcesave:    job.Status == 6,
+			Contributors: job.Contributors,
+		})
+
+		if file.Data.ID != 0 {
+			c.retireFile(ctx, previous, fmt.Sprint(file.Data.ID), filename, token)
+		}
+	}
+
+	c.audit.Emit(request.AuditEvent{
+		Type:      request.AuditCallbackSaved,
+		CompanyID: cid,
+		UserID:    uploader,
+		DealID:    parent.DealID(),
+		FileID:    fmt.Sprint(file.Data.ID),
+		Details: map[string]string{
+			"parent":       parent.String(),
+			"key":          job.Key,
+			"previous_id":  previous,
+			"filename":     filename,
+			"checksum":     transfer.Checksum,
+			"status":       fmt.Sprint(job.Status),
+			"strategy":     c.onlyoffice.Onlyoffice.Callback.SaveStrategy,
+			"contributors": strings.Join(job.Contributors, ","),
+			"replayed":     fmt.Sprint(job.Replayed),
+		},
+	})
+
+	if job.Status == 6 {
+		c.logger.Debugf("document %s has been force saved", job.Key)
+	}
+
+	return nil
+}
+
+func (c CallbackController) rotateKey(ctx context.Context, key string) error {
+	var res interface{}
+	return c.client.Call(ctx, c.client.NewRequest(
+		fmt.Sprintf("%s:builder", c.config.Namespace),
+		"DocumentKeyHandler.RotateKey", key,
+	), &res)
+}
+
+// recordRevision persists a saved file revision and returns the id of the file
+// it supersedes. Builder resolves it from the document key since a session may
+// be saved several times while the callback url keeps the initial file id, so
+// no file is returned when the revision could not be recorded.
+func (c CallbackController) recordRevision(ctx context.Context, key string, revision request.RevisionRequest) string {
+	if revision.FileID == "" || revision.FileID == "0" {
+		c.logger.Warnf("pipedrive did not return a new file id for document %s. Skipping revision", key)
+		return ""
+	}
+
+	var res response.RevisionResponse
+	if err := c.client.Call(ctx, c.client.NewRequest(
+		fmt.Sprintf("%s:builder", c.config.Namespace),
+		"RevisionInsertHandler.InsertRevision", revision,
+	), &res); err != nil {
+		c.logger.Errorf("could not record file %s revision. Keeping the superseded file: %s", revision.FileID, err.Error())
+		return ""
+	}
+
+	c.logger.Debugf("recorded revision of file %s as %s", res.PreviousID, revision.FileID)
+	return res.PreviousID
+}
+
+// retireFile applies the configured save strategy to the file superseded by current.
+func (c CallbackController) retireFile(ctx context.Context, previous, current, filename string, token model.Token) {
+	if previous == "" || previous == current {
+		return
+	}
+
+	switch c.onlyoffice.Onlyoffice.Callback.SaveStrategy {
+	case shared.SaveStrategyArchive:
+		ext := filepath.Ext(filename)
+		name := fmt.Sprintf("%s (archived %s)%s", strings.TrimSuffix(filename, ext), time.Now().UTC().Format("2006-01-02 15-04"), ext)
+		if err := c.pipedriveAPI.UpdateFile(ctx, previous, name, token); err != nil {
+			c.logger.Warnf("could not archive file %s superseded by %s: %s", previous, current, err.Error())
+			return
+		}
+
+		c.logger.Debugf("archived file %s superseded by %s as %s", previous, current, name)
+	case shared.SaveStrategyDelete:
+		if err := c.pipedriveAPI.DeleteFile(ctx, previous, token); err != nil {
+			c.logger.Warnf("could not delete file %s superseded by %s: %s", previous, current, err.Error())
+			return
+		}
+
+		c.logger.Debugf("deleted file %s superseded by %s", previous, current)
+	}
+}
+
+// resolveUploader picks the first candidate whose pipedrive tokens are still
+// valid. Revoked users or users of other companies are skipped.
+func (c CallbackController) resolveUploader(ctx context.Context, cid string, candidates []string) (string, response.UserResponse, error) {
+	err := ErrNoUploader
+	for _, uid := range candidates {
+		if ctx.Err() != nil {
+			return "", response.UserResponse{}, ctx.Err()
+		}
+
+		if identity, perr := shared.ParseUserIdentity(uid); perr == nil && fmt.Sprint(identity.CompanyID) != cid {
+			c.logger.Warnf("user %s does not belong to company %s. Skipping", uid, cid)
+			continue
+		}
+
+		var ures response.UserResponse
+		req := c.client.NewRequest(fmt.Sprintf("%s:auth", c.config.Namespace), "UserSelectHandler.GetUser", uid)
+		if err = c.client.Call(ctx, req, &ures, client.WithRetries(3), client.WithBackoff(func(ctx context.Context, req client.Request, attempts int) (time.Duration, error) {
+			return backoff.Do(attempts), nil
+		})); err != nil {
+			c.logger.Warnf("could not get user %s tokens: %s. Trying another participant", uid, err.Error())
+			continue
+		}
+
+		if ures.AccessToken == "" {
+			err = ErrNoUploader
+			c.logger.Warnf("user %s does not have a valid access token. Trying another participant", uid)
+			continue
+		}
+
+		return uid, ures, nil
+	}
+
+	return "", response.UserResponse{}, err
+}
@@ -0,0 +1,28 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package controller
+
+import "errors"
+
+var (
+	ErrEmptyFilename = errors.New("callback request does not contain a filename")
+	ErrNoUploader    = errors.New("none of the document editors has valid pipedrive tokens")
+	// Reported when neither the callback body nor the configured header carries a token.
+	ErrMissingCallbackToken = errors.New("callback request does not contain a token")
+)
@@ -0,0 +1,214 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	sessionTTL       = 24 * time.Hour
+	sessionLockTTL   = 5 * time.Second
+	sessionLockRetry = 20 * time.Millisecond
+)
+
+type sessionParticipant struct {
+	UserID   string `json:"user_id" mapstructure:"user_id"`
+	LastSeen int64  `json:"last_seen" mapstructure:"last_seen"`
+}
+
+// editingSession tracks users who joined or left a co-editing session
+// across callbacks of the same document key. Sessions are kept in the shared
+// cache since callbacks of one key may reach different replicas.
+type editingSession struct {
+	Participants []sessionParticipant `json:"participants" mapstructure:"participants"`
+}
+
+func sessionKey(key string) string {
+	return fmt.Sprintf("callback-session-%s", key)
+}
+
+func sessionLockKey(key string) string {
+	return fmt.Sprintf("callback-session-lock-%s", key)
+}
+
+func (s *editingSession) touch(uid string, seen int64) {
+	if participant := s.join(uid); participant != nil {
+		participant.LastSeen = seen
+	}
+}
+
+// join adds a user to the session without marking them as recently active.
+func (s *editingSession) join(uid string) *sessionParticipant {
+	if uid == "" {
+		return nil
+	}
+
+	for idx := range s.Participants {
+		if s.Participants[idx].UserID == uid {
+			return &s.Participants[idx]
+		}
+	}
+
+	s.Participants = append(s.Participants, sessionParticipant{UserID: uid})
+	return &s.Participants[len(s.Participants)-1]
+}
+
+// Candidates returns users able to upload a saved document ordered by
+// preference. Users reported by the document server as the last editors come
+// first, then users who have just become active and then every other
+// participant starting with the most recently seen one. Users who have just
+// left are only tried last.
+func (s editingSession) Candidates(body request.CallbackRequest) []string {
+	seen := make(map[string]bool)
+	candidates := make([]string, 0, len(body.Users)+len(s.Participants))
+	add := func(uid string) {
+		if uid != "" && !seen[uid] {
+			seen[uid] = true
+			candidates = append(candidates, uid)
+		}
+	}
+
+	for _, uid := range body.Users {
+		add(uid)
+	}
+
+	for idx := len(body.Actions) - 1; idx >= 0; idx-- {
+		if body.Actions[idx].Type != request.CallbackActionDisconnected {
+			add(body.Actions[idx].UserID)
+		}
+	}
+
+	participants := append([]sessionParticipant(nil), s.Participants...)
+	sort.SliceStable(participants, func(i, j int) bool {
+		return participants[i].LastSeen > participants[j].LastSeen
+	})
+
+	for _, participant := range participants {
+		add(participant.UserID)
+	}
+
+	for idx := len(body.Actions) - 1; idx >= 0; idx-- {
+		add(body.Actions[idx].UserID)
+	}
+
+	return candidates
+}
+
+// Contributors returns every user who took part in the session.
+func (s editingSession) Contributors(body request.CallbackRequest) []string {
+	contributors := make([]string, 0, len(s.Participants)+len(body.Users))
+	seen := make(map[string]bool)
+	for _, participant := range s.Participants {
+		if !seen[participant.UserID] {
+			seen[participant.UserID] = true
+			contributors = append(contributors, participant.UserID)
+		}
+	}
+
+	for _, uid := range body.Users {
+		if uid != "" && !seen[uid] {
+			seen[uid] = true
+			contributors = append(contributors, uid)
+		}
+	}
+
+	return contributors
+}
+
+// lockSession serializes session updates of a document key across replicas,
+// so that concurrent callbacks do not overwrite each other's participants.
+func (c CallbackController) lockSession(ctx context.Context, key string) (func(), error) {
+	lctx, cancel := context.WithTimeout(ctx, sessionLockTTL)
+	defer cancel()
+
+	for {
+		ok, err := c.cache.Acquire(lctx, sessionLockKey(key), sessionLockTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			return func() {
+				if err := c.cache.Delete(context.Background(), sessionLockKey(key)); err != nil {
+					c.logger.Debugf("could not unlock document %s session: %s", key, err.Error())
+				}
+			}, nil
+		}
+
+		select {
+		case <-lctx.Done():
+			return nil, lctx.Err()
+		case <-time.After(sessionLockRetry):
+		}
+	}
+}
+
+func (c CallbackController) trackSession(ctx context.Context, body request.CallbackRequest) editingSession {
+	// Tracking only ranks uploaders, so a session that can not be locked is
+	// still updated rather than holding the save back.
+	if unlock, err := c.lockSession(ctx, body.Key); err != nil {
+		c.logger.Warnf("could not lock document %s session: %s", body.Key, err.Error())
+	} else {
+		defer unlock()
+	}
+
+	var session editingSession
+	if res, _, err := c.cache.Get(ctx, sessionKey(body.Key)); err == nil && res != nil {
+		if err := mapstructure.Decode(res, &session); err != nil {
+			c.logger.Warnf("could not decode document %s session: %s", body.Key, err.Error())
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	for _, action := range body.Actions {
+		switch action.Type {
+		case request.CallbackActionConnected, request.CallbackActionForceSave:
+			c.logger.Debugf("user %s is active in document %s", action.UserID, body.Key)
+		case request.CallbackActionDisconnected:
+			c.logger.Debugf("user %s has left document %s", action.UserID, body.Key)
+			session.join(action.UserID)
+			continue
+		}
+
+		session.touch(action.UserID, now)
+	}
+
+	for _, uid := range body.Users {
+		session.touch(uid, now)
+	}
+
+	if err := c.cache.Put(ctx, sessionKey(body.Key), session, sessionTTL); err != nil {
+		c.logger.Warnf("could not persist document %s session: %s", body.Key, err.Error())
+	}
+
+	return session
+}
+
+func (c CallbackController) closeSession(ctx context.Context, key string) {
+	if err := c.cache.Delete(ctx, sessionKey(key)); err != nil {
+		c.logger.Debugf("could not remove document %s session: %s", key, err.Error())
+	}
+}
@@ -0,0 +1,114 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditingSession(t *testing.T) {
+	var session editingSession
+	session.touch("1:1", 1)
+	session.touch("1:2", 2)
+	session.touch("1:3", 3)
+	session.touch("1:1", 4)
+
+	body := request.CallbackRequest{
+		Users: []string{"1:2"},
+		Actions: []request.CallbackAction{
+			{Type: request.CallbackActionConnected, UserID: "1:4"},
+			{Type: request.CallbackActionDisconnected, UserID: "1:3"},
+		},
+	}
+
+	t.Run("order upload candidates", func(t *testing.T) {
+		assert.Equal(t, []string{"1:2", "1:4", "1:1", "1:3"}, session.Candidates(body))
+	})
+
+	t.Run("collect contributors", func(t *testing.T) {
+		assert.Equal(t, []string{"1:1", "1:2", "1:3"}, session.Contributors(body))
+	})
+
+	t.Run("collect candidates without a session", func(t *testing.T) {
+		assert.Equal(t, []string{"1:2", "1:4", "1:3"}, editingSession{}.Candidates(body))
+	})
+}
+
+func TestTrackSession(t *testing.T) {
+	controller := CallbackController{
+		cache:  cache.NewMemoryCache(),
+		logger: log.NewEmptyLogger(),
+	}
+
+	controller.trackSession(context.Background(), request.CallbackRequest{
+		Key: "key",
+		Actions: []request.CallbackAction{
+			{Type: request.CallbackActionConnected, UserID: "1:1"},
+		},
+	})
+
+	time.Sleep(5 * time.Millisecond)
+	controller.trackSession(context.Background(), request.CallbackRequest{
+		Key: "key",
+		Actions: []request.CallbackAction{
+			{Type: request.CallbackActionConnected, UserID: "1:2"},
+		},
+	})
+
+	time.Sleep(5 * time.Millisecond)
+	session := controller.trackSession(context.Background(), request.CallbackRequest{
+		Key: "key",
+		Actions: []request.CallbackAction{
+			{Type: request.CallbackActionDisconnected, UserID: "1:1"},
+		},
+	})
+
+	t.Run("keep users who left behind active ones", func(t *testing.T) {
+		assert.Equal(t, []string{"1:2", "1:1"}, session.Candidates(request.CallbackRequest{Key: "key"}))
+	})
+
+	t.Run("keep participants of concurrent callbacks", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for idx := 0; idx < 10; idx++ {
+			wg.Add(1)
+			go func(uid string) {
+				defer wg.Done()
+				controller.trackSession(context.Background(), request.CallbackRequest{
+					Key: "concurrent",
+					Actions: []request.CallbackAction{
+						{Type: request.CallbackActionConnected, UserID: uid},
+					},
+				})
+			}(fmt.Sprintf("1:%d", idx))
+		}
+
+		wg.Wait()
+		session := controller.trackSession(context.Background(), request.CallbackRequest{Key: "concurrent"})
+		assert.Len(t, session.Participants, 10)
+	})
+}
@@ -0,0 +1,147 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/adapter"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
+	"go.uber.org/fx"
+)
+
+type SaveWorker struct {
+	controller *CallbackController
+	queue      port.SaveQueueService
+	config     *shared.SaveQueueConfig
+	logger     plog.Logger
+}
+
+func NewSaveWorker(
+	controller *CallbackController,
+	queue port.SaveQueueService,
+	config *shared.SaveQueueConfig,
+	logger plog.Logger,
+) SaveWorker {
+	return SaveWorker{
+		controller: controller,
+		queue:      queue,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// RunSaveWorker starts processing queued saves in the background for the app's lifetime.
+func RunSaveWorker(lifecycle fx.Lifecycle, worker SaveWorker) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			for i := 0; i < worker.config.Queue.Workers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					worker.Run(ctx)
+				}()
+			}
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				wg.Wait()
+			}()
+
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+func (w SaveWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(w.config.Queue.Interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		w.Drain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain processes due jobs until the queue has nothing left to offer.
+func (w SaveWorker) Drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := w.queue.Next(ctx)
+		if err != nil {
+			if !errors.Is(err, adapter.ErrNoJob) {
+				w.logger.Errorf("could not claim a save job. Reason: %s", err.Error())
+			}
+
+			return
+		}
+
+		w.Process(ctx, job)
+	}
+}
+
+func (w SaveWorker) Process(ctx context.Context, job domain.SaveJob) {
+	err := w.controller.saveFile(ctx, job)
+	if ctx.Err() != nil {
+		// The lease expires and another worker picks the job up after a restart.
+		return
+	}
+
+	if err == nil {
+		if err := w.queue.Complete(ctx, job); err != nil {
+			w.logger.Errorf("could not complete save job %s. Reason: %s", job.ID, err.Error())
+		}
+
+		return
+	}
+
+	w.logger.Warnf("could not save document %s (job %s, attempt %d): %s", job.Key, job.ID, job.Attempts+1, err.Error())
+	if _, err := w.queue.Fail(ctx, job, err, isPermanentSaveError(err)); err != nil {
+		w.logger.Errorf("could not reschedule save job %s. Reason: %s", job.ID, err.Error())
+	}
+}
+
+// isPermanentSaveError reports whether retrying the upload can never succeed.
+func isPermanentSaveError(err error) bool {
+	return errors.Is(err, ErrEmptyFilename) ||
+		errors.Is(err, model.ErrInvalidParent) ||
+		errors.Is(err, pclient.ErrInvalidContentLength) ||
+		errors.Is(err, pclient.ErrInvalidUrlFormat)
+}
@@ -0,0 +1,187 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/port"
+)
+
+type memorySaveQueueAdapter struct {
+	mu   sync.Mutex
+	jobs map[string]domain.SaveJob
+	dead map[string]domain.SaveJob
+}
+
+func NewMemorySaveQueueAdapter() port.SaveQueueServiceAdapter {
+	return &memorySaveQueueAdapter{
+		jobs: make(map[string]domain.SaveJob),
+		dead: make(map[string]domain.SaveJob),
+	}
+}
+
+func (m *memorySaveQueueAdapter) InsertJob(ctx context.Context, job domain.SaveJob) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.jobs[job.ID]; ok {
+		return nil
+	}
+
+	m.jobs[job.ID] = job
+	return nil
+}
+
+func (m *memorySaveQueueAdapter) ClaimJob(ctx context.Context, now time.Time, lease time.Duration) (domain.SaveJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	jobs := make([]domain.SaveJob, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, job)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+	})
+
+	seen := make(map[string]bool)
+	for _, job := range jobs {
+		if seen[job.Key] {
+			continue
+		}
+
+		seen[job.Key] = true
+		if job.NextAttemptAt.After(now) {
+			continue
+		}
+
+		job.State = domain.JobProcessing
+		job.NextAttemptAt = now.Add(lease)
+		m.jobs[job.ID] = job
+		return job, nil
+	}
+
+	return domain.SaveJob{}, ErrNoJob
+}
+
+func (m *memorySaveQueueAdapter) SelectJobs(ctx context.Context, key string) ([]domain.SaveJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	jobs := make([]domain.SaveJob, 0)
+	for _, job := range m.jobs {
+		if job.Key == key {
+			jobs = append(jobs, job)
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+	})
+
+	return jobs, nil
+}
+
+func (m *memorySaveQueueAdapter) UpdateJob(ctx context.Context, job domain.SaveJob) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.jobs[job.ID]
+	if !ok {
+		return ErrNoJob
+	}
+
+	stored.State = job.State
+	stored.Attempts = job.Attempts
+	stored.LastError = job.LastError
+	stored.NextAttemptAt = job.NextAttemptAt
+	m.jobs[job.ID] = stored
+	return nil
+}
+
+func (m *memorySaveQueueAdapter) DeleteJob(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.jobs[id]; !ok {
+		return ErrNoJob
+	}
+
+	delete(m.jobs, id)
+	return nil
+}
+
+func (m *memorySaveQueueAdapter) BuryJob(ctx context.Context, job domain.SaveJob) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job.State = domain.JobDead
+	m.dead[job.ID] = job
+	delete(m.jobs, job.ID)
+	return nil
+}
+
+func (m *memorySaveQueueAdapter) SelectDeadJobs(ctx context.Context, cid string) ([]domain.SaveJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	jobs := make([]domain.SaveJob, 0)
+	for _, job := range m.dead {
+		if cid == "" || job.CompanyID == cid {
+			jobs = append(jobs, job)
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+	})
+
+	return jobs, nil
+}
+
+func (m *memorySaveQueueAdapter) DeleteDeadJob(ctx context.Context, id string) (domain.SaveJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.dead[id]
+	if !ok {
+		return job, ErrNoJob
+	}
+
+	delete(m.dead, id)
+	return job, nil
+}
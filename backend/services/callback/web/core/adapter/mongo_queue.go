@@ -0,0 +1,363 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/port"
+	"github.com/kamva/mgm/v3"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// claimBatch bounds the number of due jobs inspected per claim attempt.
+const claimBatch = 32
+
+type saveJobFields struct {
+	JobID         string    `json:"job_id" bson:"job_id"`
+	Key           string    `json:"key" bson:"key"`
+	Status        int       `json:"status" bson:"status"`
+	URL           string    `json:"url" bson:"url"`
+	CompanyID     string    `json:"company_id" bson:"company_id"`
+	Parent        string    `json:"parent" bson:"parent"`
+	FileID        string    `json:"file_id" bson:"file_id"`
+	Filename      string    `json:"filename" bson:"filename"`
+	Candidates    []string  `json:"candidates" bson:"candidates"`
+	Contributors  []string  `json:"contributors" bson:"contributors"`
+	State         string    `json:"state" bson:"state"`
+	Attempts      int       `json:"attempts" bson:"attempts"`
+	LastError     string    `json:"last_error" bson:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at" bson:"next_attempt_at"`
+	Replayed      bool      `json:"replayed" bson:"replayed"`
+}
+
+func newSaveJobFields(job domain.SaveJob) saveJobFields {
+	return saveJobFields{
+		JobID:         job.ID,
+		Key:           job.Key,
+		Status:        job.Status,
+		URL:           job.URL,
+		CompanyID:     job.CompanyID,
+		Parent:        job.Parent,
+		FileID:        job.FileID,
+		Filename:      job.Filename,
+		Candidates:    job.Candidates,
+		Contributors:  job.Contributors,
+		State:         job.State,
+		Attempts:      job.Attempts,
+		LastError:     job.LastError,
+		NextAttemptAt: job.NextAttemptAt,
+		Replayed:      job.Replayed,
+	}
+}
+
+func (f saveJobFields) toDomain(createdAt time.Time) domain.SaveJob {
+	return domain.SaveJob{
+		ID:            f.JobID,
+		Key:           f.Key,
+		Status:        f.Status,
+		URL:           f.URL,
+		CompanyID:     f.CompanyID,
+		Parent:        f.Parent,
+		FileID:        f.FileID,
+		Filename:      f.Filename,
+		Candidates:    f.Candidates,
+		Contributors:  f.Contributors,
+		State:         f.State,
+		Attempts:      f.Attempts,
+		LastError:     f.LastError,
+		NextAttemptAt: f.NextAttemptAt,
+		Replayed:      f.Replayed,
+		CreatedAt:     createdAt,
+	}
+}
+
+type saveJobCollection struct {
+	mgm.DefaultModel `bson:",inline"`
+	saveJobFields    `bson:",inline"`
+}
+
+type deadSaveJobCollection struct {
+	mgm.DefaultModel `bson:",inline"`
+	saveJobFields    `bson:",inline"`
+}
+
+type mongoSaveQueueAdapter struct {
+}
+
+func NewMongoSaveQueueAdapter(url string) port.SaveQueueServiceAdapter {
+	if err := mgm.SetDefaultConfig(
+		&mgm.Config{CtxTimeout: 3 * time.Second}, "pipedrive",
+		options.Client().ApplyURI(url),
+	); err != nil {
+		log.Fatalf("mongo initialization error: %s", err.Error())
+	}
+
+	// The unique job index turns document server retries of the same callback into no-ops.
+	if _, err := mgm.Coll(&saveJobCollection{}).Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "job_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "created_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "key", Value: 1}, {Key: "created_at", Value: 1}},
+		},
+	}); err != nil {
+		log.Printf("could not create save job indexes: %s", err.Error())
+	}
+
+	if _, err := mgm.Coll(&deadSaveJobCollection{}).Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "job_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "company_id", Value: 1}},
+		},
+	}); err != nil {
+		log.Printf("could not create dead save job indexes: %s", err.Error())
+	}
+
+	return &mongoSaveQueueAdapter{}
+}
+
+func (m *mongoSaveQueueAdapter) InsertJob(ctx context.Context, job domain.SaveJob) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+
+	// Replayed jobs keep their creation time to stay ordered among saves of
+	// the same document.
+	now := time.Now().UTC()
+	createdAt := now
+	if !job.CreatedAt.IsZero() {
+		createdAt = job.CreatedAt
+	}
+
+	fields := newSaveJobFields(job)
+	_, err := mgm.Coll(&saveJobCollection{}).UpdateOne(
+		ctx,
+		bson.M{"job_id": job.ID},
+		bson.M{"$setOnInsert": bson.M{
+			"job_id":          fields.JobID,
+			"key":             fields.Key,
+			"status":          fields.Status,
+			"url":             fields.URL,
+			"company_id":      fields.CompanyID,
+			"parent":          fields.Parent,
+			"file_id":         fields.FileID,
+			"filename":        fields.Filename,
+			"candidates":      fields.Candidates,
+			"contributors":    fields.Contributors,
+			"state":           fields.State,
+			"attempts":        fields.Attempts,
+			"last_error":      fields.LastError,
+			"next_attempt_at": fields.NextAttemptAt,
+			"replayed":        fields.Replayed,
+			"created_at":      createdAt,
+			"updated_at":      now,
+		}},
+		options.Update().SetUpsert(true),
+	)
+
+	return err
+}
+
+// ClaimJob leases the oldest due job whose document has no older pending save,
+// so that saves of a single document are uploaded in order.
+func (m *mongoSaveQueueAdapter) ClaimJob(ctx context.Context, now time.Time, lease time.Duration) (domain.SaveJob, error) {
+	var candidates []saveJobCollection
+	if err := mgm.Coll(&saveJobCollection{}).SimpleFindWithCtx(
+		ctx, &candidates, bson.M{"next_attempt_at": bson.M{"$lte": now}},
+		options.Find().SetSort(bson.M{"created_at": 1}).SetLimit(claimBatch),
+	); err != nil {
+		return domain.SaveJob{}, err
+	}
+
+	for _, candidate := range candidates {
+		older, err := mgm.Coll(&saveJobCollection{}).CountDocuments(ctx, bson.M{
+			"key":        candidate.Key,
+			"created_at": bson.M{"$lt": candidate.CreatedAt},
+		})
+		if err != nil {
+			return domain.SaveJob{}, err
+		}
+
+		if older > 0 {
+			continue
+		}
+
+		result := &saveJobCollection{}
+		if err := mgm.Coll(result).FindOneAndUpdate(
+			ctx,
+			bson.M{"job_id": candidate.JobID, "next_attempt_at": bson.M{"$lte": now}},
+			bson.M{"$set": bson.M{
+				"state":           domain.JobProcessing,
+				"next_attempt_at": now.Add(lease),
+				"updated_at":      time.Now().UTC(),
+			}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(result); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				continue
+			}
+
+			return domain.SaveJob{}, err
+		}
+
+		return result.toDomain(result.CreatedAt), nil
+	}
+
+	return domain.SaveJob{}, ErrNoJob
+}
+
+func (m *mongoSaveQueueAdapter) SelectJobs(ctx context.Context, key string) ([]domain.SaveJob, error) {
+	var results []saveJobCollection
+	if err := mgm.Coll(&saveJobCollection{}).SimpleFindWithCtx(
+		ctx, &results, bson.M{"key": strings.TrimSpace(key)},
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.SaveJob, 0, len(results))
+	for _, result := range results {
+		jobs = append(jobs, result.toDomain(result.CreatedAt))
+	}
+
+	return jobs, nil
+}
+
+func (m *mongoSaveQueueAdapter) UpdateJob(ctx context.Context, job domain.SaveJob) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+
+	res, err := mgm.Coll(&saveJobCollection{}).UpdateOne(
+		ctx,
+		bson.M{"job_id": job.ID},
+		bson.M{"$set": bson.M{
+			"state":           job.State,
+			"attempts":        job.Attempts,
+			"last_error":      job.LastError,
+			"next_attempt_at": job.NextAttemptAt,
+			"updated_at":      time.Now().UTC(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return ErrNoJob
+	}
+
+	return nil
+}
+
+func (m *mongoSaveQueueAdapter) DeleteJob(ctx context.Context, id string) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return ErrInvalidJobID
+	}
+
+	res, err := mgm.Coll(&saveJobCollection{}).DeleteOne(ctx, bson.M{"job_id": id})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return ErrNoJob
+	}
+
+	return nil
+}
+
+func (m *mongoSaveQueueAdapter) BuryJob(ctx context.Context, job domain.SaveJob) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+
+	job.State = domain.JobDead
+	dead := &deadSaveJobCollection{saveJobFields: newSaveJobFields(job)}
+	dead.CreatedAt = job.CreatedAt
+	dead.UpdatedAt = time.Now().UTC()
+	if _, err := mgm.Coll(dead).ReplaceOne(
+		ctx, bson.M{"job_id": job.ID}, dead,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		return err
+	}
+
+	if _, err := mgm.Coll(&saveJobCollection{}).DeleteOne(ctx, bson.M{"job_id": job.ID}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoSaveQueueAdapter) SelectDeadJobs(ctx context.Context, cid string) ([]domain.SaveJob, error) {
+	filter := bson.M{}
+	if cid = strings.TrimSpace(cid); cid != "" {
+		filter["company_id"] = cid
+	}
+
+	var results []deadSaveJobCollection
+	if err := mgm.Coll(&deadSaveJobCollection{}).SimpleFindWithCtx(
+		ctx, &results, filter,
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.SaveJob, 0, len(results))
+	for _, result := range results {
+		jobs = append(jobs, result.toDomain(result.CreatedAt))
+	}
+
+	return jobs, nil
+}
+
+func (m *mongoSaveQueueAdapter) DeleteDeadJob(ctx context.Context, id string) (domain.SaveJob, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return domain.SaveJob{}, ErrInvalidJobID
+	}
+
+	result := &deadSaveJobCollection{}
+	if err := mgm.Coll(result).FindOneAndDelete(ctx, bson.M{"job_id": id}).Decode(result); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.SaveJob{}, ErrNoJob
+		}
+
+		return domain.SaveJob{}, err
+	}
+
+	return result.toDomain(result.CreatedAt), nil
+}
@@ -0,0 +1,114 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	JobPending    = "pending"
+	JobProcessing = "processing"
+	JobDead       = "dead"
+)
+
+// SaveJob is an upload of a document saved by the document server.
+type SaveJob struct {
+	ID            string    `json:"id" mapstructure:"id"`
+	Key           string    `json:"key" mapstructure:"key"`
+	Status        int       `json:"status" mapstructure:"status"`
+	URL           string    `json:"url" mapstructure:"url"`
+	CompanyID     string    `json:"company_id" mapstructure:"company_id"`
+	Parent        string    `json:"parent" mapstructure:"parent"`
+	FileID        string    `json:"file_id" mapstructure:"file_id"`
+	Filename      string    `json:"filename" mapstructure:"filename"`
+	Candidates    []string  `json:"candidates" mapstructure:"candidates"`
+	Contributors  []string  `json:"contributors" mapstructure:"contributors"`
+	State         string    `json:"state" mapstructure:"state"`
+	Attempts      int       `json:"attempts" mapstructure:"attempts"`
+	LastError     string    `json:"last_error" mapstructure:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at" mapstructure:"next_attempt_at"`
+	Replayed      bool      `json:"replayed" mapstructure:"replayed"`
+	CreatedAt     time.Time `json:"created_at" mapstructure:"created_at"`
+}
+
+func (j SaveJob) ToJSON() []byte {
+	buf, _ := json.Marshal(j)
+	return buf
+}
+
+func (j *SaveJob) Validate() error {
+	j.ID = strings.TrimSpace(j.ID)
+	j.Key = strings.TrimSpace(j.Key)
+	j.URL = strings.TrimSpace(j.URL)
+	j.CompanyID = strings.TrimSpace(j.CompanyID)
+	j.FileID = strings.TrimSpace(j.FileID)
+
+	if j.ID == "" {
+		return &InvalidModelFieldError{
+			Model:  "SaveJob",
+			Field:  "ID",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if j.Key == "" {
+		return &InvalidModelFieldError{
+			Model:  "SaveJob",
+			Field:  "Key",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if j.URL == "" {
+		return &InvalidModelFieldError{
+			Model:  "SaveJob",
+			Field:  "URL",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if j.CompanyID == "" {
+		return &InvalidModelFieldError{
+			Model:  "SaveJob",
+			Field:  "CompanyID",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if j.FileID == "" {
+		return &InvalidModelFieldError{
+			Model:  "SaveJob",
+			Field:  "FileID",
+			Reason: "Should not be empty",
+		}
+	}
+
+	if j.Attempts < 0 {
+		return &InvalidModelFieldError{
+			Model:  "SaveJob",
+			Field:  "Attempts",
+			Reason: "Should not be negative",
+		}
+	}
+
+	return nil
+}
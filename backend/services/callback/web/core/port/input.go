@@ -0,0 +1,34 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package port
+
+import (
+	"context"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/domain"
+)
+
+type SaveQueueService interface {
+	Enqueue(ctx context.Context, job domain.SaveJob) (domain.SaveJob, error)
+	Next(ctx context.Context) (domain.SaveJob, error)
+	Complete(ctx context.Context, job domain.SaveJob) error
+	Fail(ctx context.Context, job domain.SaveJob, reason error, permanent bool) (domain.SaveJob, error)
+	GetDeadJobs(ctx context.Context, cid string) ([]domain.SaveJob, error)
+	Replay(ctx context.Context, id string) (domain.SaveJob, error)
+}
@@ -0,0 +1,37 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/domain"
+)
+
+type SaveQueueServiceAdapter interface {
+	InsertJob(ctx context.Context, job domain.SaveJob) error
+	ClaimJob(ctx context.Context, now time.Time, lease time.Duration) (domain.SaveJob, error)
+	SelectJobs(ctx context.Context, key string) ([]domain.SaveJob, error)
+	UpdateJob(ctx context.Context, job domain.SaveJob) error
+	DeleteJob(ctx context.Context, id string) error
+	BuryJob(ctx context.Context, job domain.SaveJob) error
+	SelectDeadJobs(ctx context.Context, cid string) ([]domain.SaveJob, error)
+	DeleteDeadJob(ctx context.Context, id string) (domain.SaveJob, error)
+}
@@ -0,0 +1,175 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+)
+
+type saveQueueService struct {
+	adapter port.SaveQueueServiceAdapter
+	config  *shared.SaveQueueConfig
+	logger  plog.Logger
+}
+
+func NewSaveQueueService(
+	adapter port.SaveQueueServiceAdapter,
+	config *shared.SaveQueueConfig,
+	logger plog.Logger,
+) port.SaveQueueService {
+	return saveQueueService{
+		adapter: adapter,
+		config:  config,
+		logger:  logger,
+	}
+}
+
+// NewSaveJobID derives a job id from the callback so that document server
+// retries of a single save collapse into one job.
+func NewSaveJobID(key string, status int, url string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d:%s", key, status, url)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Backoff returns the delay before the given attempt is retried.
+func (s saveQueueService) Backoff(attempts int) time.Duration {
+	delay := time.Duration(s.config.Queue.BaseDelay) * time.Second
+	limit := time.Duration(s.config.Queue.MaxDelay) * time.Second
+	for i := 1; i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+
+	if delay > limit {
+		return limit
+	}
+
+	return delay
+}
+
+func (s saveQueueService) Enqueue(ctx context.Context, job domain.SaveJob) (domain.SaveJob, error) {
+	if job.ID == "" {
+		job.ID = NewSaveJobID(job.Key, job.Status, job.URL)
+	}
+
+	now := time.Now().UTC()
+	job.State = domain.JobPending
+	job.Attempts = 0
+	job.LastError = ""
+	job.NextAttemptAt = now
+	job.CreatedAt = now
+
+	s.logger.Debugf("enqueueing save job %s for document %s", job.ID, job.Key)
+	if err := s.adapter.InsertJob(ctx, job); err != nil {
+		return job, err
+	}
+
+	return job, nil
+}
+
+func (s saveQueueService) Next(ctx context.Context) (domain.SaveJob, error) {
+	return s.adapter.ClaimJob(ctx, time.Now().UTC(), time.Duration(s.config.Queue.Lease)*time.Second)
+}
+
+func (s saveQueueService) Complete(ctx context.Context, job domain.SaveJob) error {
+	s.logger.Debugf("save job %s has been completed after %d attempts", job.ID, job.Attempts+1)
+	return s.adapter.DeleteJob(ctx, job.ID)
+}
+
+// Fail schedules the job for another attempt or moves it to the dead letter
+// collection once it has run out of attempts or the failure is permanent.
+func (s saveQueueService) Fail(ctx context.Context, job domain.SaveJob, reason error, permanent bool) (domain.SaveJob, error) {
+	job.Attempts++
+	if reason != nil {
+		job.LastError = reason.Error()
+	}
+
+	if permanent || job.Attempts >= s.config.Queue.MaxAttempts {
+		s.logger.Warnf("save job %s is moved to the dead letter collection after %d attempts. Reason: %s", job.ID, job.Attempts, job.LastError)
+		job.State = domain.JobDead
+		return job, s.adapter.BuryJob(ctx, job)
+	}
+
+	job.State = domain.JobPending
+	job.NextAttemptAt = time.Now().UTC().Add(s.Backoff(job.Attempts))
+	s.logger.Debugf("save job %s will be retried at %s", job.ID, job.NextAttemptAt.Format(time.RFC3339))
+	return job, s.adapter.UpdateJob(ctx, job)
+}
+
+func (s saveQueueService) GetDeadJobs(ctx context.Context, cid string) ([]domain.SaveJob, error) {
+	return s.adapter.SelectDeadJobs(ctx, strings.TrimSpace(cid))
+}
+
+func (s saveQueueService) Replay(ctx context.Context, id string) (domain.SaveJob, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return domain.SaveJob{}, &InvalidServiceParameterError{
+			Name:   "ID",
+			Reason: "Should not be blank",
+		}
+	}
+
+	job, err := s.adapter.DeleteDeadJob(ctx, id)
+	if err != nil {
+		return job, err
+	}
+
+	restore := func() {
+		if berr := s.adapter.BuryJob(ctx, job); berr != nil {
+			s.logger.Errorf("could not restore dead save job %s. Reason: %s", job.ID, berr.Error())
+		}
+	}
+
+	pending, err := s.adapter.SelectJobs(ctx, job.Key)
+	if err != nil {
+		restore()
+		return job, err
+	}
+
+	if len(pending) > 0 {
+		s.logger.Warnf("dead save job %s is superseded by %d pending saves of document %s", job.ID, len(pending), job.Key)
+		restore()
+		return job, ErrReplaySuperseded
+	}
+
+	// The job keeps its creation time so that it is still ordered among saves
+	// of the same document. Newer saves may have completed since it died, so
+	// a replayed upload must never retire the file they produced.
+	job.State = domain.JobPending
+	job.Attempts = 0
+	job.LastError = ""
+	job.NextAttemptAt = time.Now().UTC()
+	job.Replayed = true
+	if err := s.adapter.InsertJob(ctx, job); err != nil {
+		restore()
+		return job, err
+	}
+
+	s.logger.Infof("dead save job %s has been replayed", job.ID)
+	return job, nil
+}
@@ -0,0 +1,174 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/adapter"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/callback/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+var saveJob = domain.SaveJob{
+	Key:        "mock",
+	Status:     2,
+	URL:        "https://example.com/download",
+	CompanyID:  "1",
+	Parent:     "deal:1",
+	FileID:     "1",
+	Filename:   "mock.docx",
+	Candidates: []string{"1:1"},
+}
+
+func newQueueConfig() *shared.SaveQueueConfig {
+	var config shared.SaveQueueConfig
+	config.Queue.Workers = 1
+	config.Queue.Interval = 1
+	config.Queue.Lease = 300
+	config.Queue.MaxAttempts = 2
+	config.Queue.BaseDelay = 5
+	config.Queue.MaxDelay = 30
+	return &config
+}
+
+func TestSaveQueueBackoff(t *testing.T) {
+	service := saveQueueService{config: newQueueConfig()}
+	for attempts, delay := range map[int]time.Duration{
+		1: 5 * time.Second,
+		2: 10 * time.Second,
+		3: 20 * time.Second,
+		4: 30 * time.Second,
+		9: 30 * time.Second,
+	} {
+		assert.Equal(t, delay, service.Backoff(attempts))
+	}
+}
+
+func TestSaveQueueService(t *testing.T) {
+	ctx := context.Background()
+	service := NewSaveQueueService(adapter.NewMemorySaveQueueAdapter(), newQueueConfig(), log.NewEmptyLogger())
+
+	t.Run("enqueue the same callback twice", func(t *testing.T) {
+		first, err := service.Enqueue(ctx, saveJob)
+		assert.NoError(t, err)
+
+		second, err := service.Enqueue(ctx, saveJob)
+		assert.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID)
+	})
+
+	t.Run("enqueue a later save of the same document", func(t *testing.T) {
+		later := saveJob
+		later.URL = "https://example.com/later"
+		_, err := service.Enqueue(ctx, later)
+		assert.NoError(t, err)
+	})
+
+	t.Run("claim jobs of a document in order", func(t *testing.T) {
+		job, err := service.Next(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, saveJob.URL, job.URL)
+		assert.Equal(t, domain.JobProcessing, job.State)
+
+		_, err = service.Next(ctx)
+		assert.ErrorIs(t, err, adapter.ErrNoJob)
+	})
+
+	t.Run("retry a failed job with backoff", func(t *testing.T) {
+		job, err := service.Fail(ctx, domain.SaveJob{
+			ID: NewSaveJobID(saveJob.Key, saveJob.Status, saveJob.URL), Key: saveJob.Key, URL: saveJob.URL,
+			CompanyID: saveJob.CompanyID, FileID: saveJob.FileID,
+		}, errors.New("mock"), false)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, job.Attempts)
+		assert.Equal(t, domain.JobPending, job.State)
+		assert.True(t, job.NextAttemptAt.After(time.Now()))
+	})
+
+	t.Run("bury a job that has run out of attempts", func(t *testing.T) {
+		job, err := service.Fail(ctx, domain.SaveJob{
+			ID: NewSaveJobID(saveJob.Key, saveJob.Status, saveJob.URL), Key: saveJob.Key, URL: saveJob.URL,
+			CompanyID: saveJob.CompanyID, FileID: saveJob.FileID, Attempts: 1,
+		}, errors.New("mock"), false)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.JobDead, job.State)
+
+		jobs, err := service.GetDeadJobs(ctx, saveJob.CompanyID)
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 1)
+		assert.Equal(t, "mock", jobs[0].LastError)
+	})
+
+	t.Run("claim the next save once the previous one is dead", func(t *testing.T) {
+		job, err := service.Next(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/later", job.URL)
+		assert.NoError(t, service.Complete(ctx, job))
+	})
+
+	t.Run("refuse to replay a dead job superseded by a pending save", func(t *testing.T) {
+		pending := saveJob
+		pending.URL = "https://example.com/pending"
+		pending, err := service.Enqueue(ctx, pending)
+		assert.NoError(t, err)
+
+		_, err = service.Replay(ctx, NewSaveJobID(saveJob.Key, saveJob.Status, saveJob.URL))
+		assert.ErrorIs(t, err, ErrReplaySuperseded)
+
+		jobs, err := service.GetDeadJobs(ctx, "")
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 1)
+
+		job, err := service.Next(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, pending.URL, job.URL)
+		assert.NoError(t, service.Complete(ctx, job))
+	})
+
+	t.Run("replay a dead job", func(t *testing.T) {
+		dead, err := service.GetDeadJobs(ctx, "")
+		assert.NoError(t, err)
+		assert.Len(t, dead, 1)
+
+		job, err := service.Replay(ctx, NewSaveJobID(saveJob.Key, saveJob.Status, saveJob.URL))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, job.Attempts)
+		assert.True(t, job.Replayed)
+		assert.Equal(t, dead[0].CreatedAt, job.CreatedAt)
+
+		jobs, err := service.GetDeadJobs(ctx, "")
+		assert.NoError(t, err)
+		assert.Empty(t, jobs)
+
+		job, err = service.Next(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, saveJob.URL, job.URL)
+	})
+
+	t.Run("replay an unknown job", func(t *testing.T) {
+		_, err := service.Replay(ctx, "unknown")
+		assert.ErrorIs(t, err, adapter.ErrNoJob)
+	})
+}
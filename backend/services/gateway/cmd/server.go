@@ -23,9 +23,13 @@ import (
 	chttp "github.com/ONLYOFFICE/onlyoffice-integration-adapters/service/http"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/controller"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/adapter"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/service"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/middleware"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
 	"github.com/urfave/cli/v2"
 )
 
@@ -58,6 +62,16 @@ func Server() *cli.Command {
 				client.NewPipedriveAuthClient,
 				shared.BuildNewIntegrationCredentialsConfig(CONFIG_PATH),
 				shared.BuildNewOnlyofficeConfig(CONFIG_PATH),
+				shared.BuildNewDownloadConfig(CONFIG_PATH),
+				shared.BuildNewDistributedCacheConfig(CONFIG_PATH),
+				cache.NewCache,
+				shared.BuildNewTemplatesConfig(CONFIG_PATH),
+				adapter.BuildNewTemplateAdapter,
+				service.NewTemplateService,
+				service.NewMergeService,
+				shared.NewAuditEmitter,
+			), pkg.WithInvokables(
+				cache.BuildRunInvalidator(request.EventUserUpdated, request.EventUserDeleted),
 			)).Bootstrap()
 
 			if err := app.Err(); err != nil {
@@ -24,51 +24,98 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
-	"sync/atomic"
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
 	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+	"github.com/mitchellh/mapstructure"
 	"go-micro.dev/v4/client"
 	"golang.org/x/sync/errgroup"
 )
 
 var ErrNotAdmin = errors.New("no admin access")
 
+// Admin rights may change in pipedrive without an event, so profiles are not kept for long.
+const profileTTL = 1 * time.Minute
+
 type ApiController struct {
-	client        client.Client
-	apiClient     pclient.PipedriveApiClient
-	commandClient pclient.CommandClient
-	jwtManager    crypto.JwtManager
-	config        *config.ServerConfig
-	logger        log.Logger
+	client          client.Client
+	apiClient       pclient.PipedriveApiClient
+	commandClient   pclient.CommandClient
+	templateService port.TemplateService
+	jwtManager      crypto.JwtManager
+	config          *config.ServerConfig
+	templates       *shared.TemplatesConfig
+	audit           shared.AuditEmitter
+	profiles        cache.Cache
+	logger          log.Logger
 }
 
 func NewApiController(
 	client client.Client,
 	apiClient pclient.PipedriveApiClient,
 	commandClient pclient.CommandClient,
+	templateService port.TemplateService,
 	jwtManager crypto.JwtManager,
 	serverConfig *config.ServerConfig,
+	templates *shared.TemplatesConfig,
+	audit shared.AuditEmitter,
+	profiles cache.Cache,
 	logger log.Logger,
 ) ApiController {
 	return ApiController{
-		client:        client,
-		apiClient:     apiClient,
-		commandClient: commandClient,
-		jwtManager:    jwtManager,
-		config:        serverConfig,
-		logger:        logger,
+		client:          client,
+		apiClient:       apiClient,
+		commandClient:   commandClient,
+		templateService: templateService,
+		jwtManager:      jwtManager,
+		config:          serverConfig,
+		templates:       templates,
+		audit:           audit,
+		profiles:        profiles,
+		logger:          logger,
 	}
 }
 
+// getProfile returns the pipedrive profile of a user. Profiles are cached briefly
+// and dropped as soon as the auth service reports a user change.
+func (c *ApiController) getProfile(ctx context.Context, id string, ures response.UserResponse) (model.User, error) {
+	var usr model.User
+	if res, _, err := c.profiles.Get(ctx, id); err == nil && res != nil {
+		if err := mapstructure.Decode(res, &usr); err == nil && usr.ID != 0 {
+			return usr, nil
+		}
+	}
+
+	usr, err := c.apiClient.GetMe(ctx, model.Token{
+		AccessToken:  ures.AccessToken,
+		RefreshToken: ures.RefreshToken,
+		TokenType:    ures.TokenType,
+		Scope:        ures.Scope,
+		ApiDomain:    ures.ApiDomain,
+	})
+	if err != nil {
+		return usr, err
+	}
+
+	if err := c.profiles.Put(ctx, id, usr, profileTTL); err != nil {
+		c.logger.Warnf("could not cache user %s profile: %s", id, err.Error())
+	}
+
+	return usr, nil
+}
+
 func (c *ApiController) getUser(ctx context.Context, id string) (response.UserResponse, int, error) {
 	var ures response.UserResponse
 	if err := c.client.Call(ctx, c.client.NewRequest(fmt.Sprintf("%s:auth", c.config.Namespace), "UserSelectHandler.GetUser", id), &ures); err != nil {
@@ -101,7 +148,7 @@ func (c ApiController) BuildGetMe() http.HandlerFunc {
 		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 		defer cancel()
 
-		ures, status, _ := c.getUser(ctx, fmt.Sprint(pctx.UID+pctx.CID))
+		ures, status, _ := c.getUser(ctx, shared.NewUserIdentity(pctx.UID, pctx.CID).String())
 		if status != http.StatusOK {
 			rw.WriteHeader(status)
 			return
@@ -148,8 +195,6 @@ func (c ApiController) BuildPostSettings() http.HandlerFunc {
 		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 		defer cancel()
 
-		var companyID int64
-
 		eg, ectx := errgroup.WithContext(ctx)
 
 		if !settings.DemoEnabled {
@@ -158,7 +203,7 @@ func (c ApiController) BuildPostSettings() http.HandlerFunc {
 				case <-ectx.Done():
 					return ectx.Err()
 				default:
-					if err := c.commandClient.License(ectx, settings.DocAddress, settings.DocSecret); err != nil {
+					if _, err := c.commandClient.Version(ectx, settings.DocAddress, settings.DocSecret, settings.DocHeader); err != nil {
 						c.logger.Errorf("could not validate ONLYOFFICE document server credentials: %s", err.Error())
 						return err
 					}
@@ -174,30 +219,11 @@ func (c ApiController) BuildPostSettings() http.HandlerFunc {
 			case <-ectx.Done():
 				return ectx.Err()
 			default:
-				ures, _, err := c.getUser(ectx, fmt.Sprint(pctx.UID+pctx.CID))
-				if err != nil {
-					return err
-				}
-
-				urs, err := c.apiClient.GetMe(ectx, model.Token{
-					AccessToken:  ures.AccessToken,
-					RefreshToken: ures.RefreshToken,
-					TokenType:    ures.TokenType,
-					Scope:        ures.Scope,
-					ApiDomain:    ures.ApiDomain,
-				})
-				if err != nil {
+				if _, err := c.checkAdmin(ectx, pctx); err != nil {
 					c.logger.Errorf("could not get pipedrive user or no user has admin permissions")
 					return err
 				}
 
-				for _, access := range urs.Access {
-					if access.App == "global" && !access.Admin {
-						return ErrNotAdmin
-					}
-				}
-
-				atomic.StoreInt64(&companyID, int64(urs.CompanyID))
 				return nil
 			}
 		})
@@ -218,11 +244,13 @@ func (c ApiController) BuildPostSettings() http.HandlerFunc {
 		}
 
 		sreq := request.DocSettings{
-			CompanyID:   int(atomic.LoadInt64(&companyID)),
+			CompanyID:   pctx.CID,
 			DocAddress:  settings.DocAddress,
 			DocHeader:   settings.DocHeader,
 			DocSecret:   settings.DocSecret,
 			DemoEnabled: settings.DemoEnabled,
+			Permissions: settings.Permissions,
+			Roles:       settings.Roles,
 		}
 
 		tctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -253,6 +281,17 @@ func (c ApiController) BuildPostSettings() http.HandlerFunc {
 			return
 		}
 
+		c.audit.Emit(request.AuditEvent{
+			Type:      request.AuditSettingsPosted,
+			CompanyID: fmt.Sprint(pctx.CID),
+			UserID:    fmt.Sprint(pctx.UID),
+			Details: map[string]string{
+				"doc_address":  settings.DocAddress,
+				"doc_header":   settings.DocHeader,
+				"demo_enabled": fmt.Sprint(settings.DemoEnabled),
+			},
+		})
+
 		rw.WriteHeader(http.StatusCreated)
 	}
 }
@@ -270,19 +309,13 @@ func (c ApiController) BuildGetSettings() http.HandlerFunc {
 		ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
 		defer cancel()
 
-		ures, status, _ := c.getUser(ctx, fmt.Sprint(pctx.UID+pctx.CID))
+		ures, status, _ := c.getUser(ctx, shared.NewUserIdentity(pctx.UID, pctx.CID).String())
 		if status != http.StatusOK {
 			rw.WriteHeader(status)
 			return
 		}
 
-		urs, _ := c.apiClient.GetMe(ctx, model.Token{
-			AccessToken:  ures.AccessToken,
-			RefreshToken: ures.RefreshToken,
-			TokenType:    ures.TokenType,
-			Scope:        ures.Scope,
-			ApiDomain:    ures.ApiDomain,
-		})
+		urs, _ := c.getProfile(ctx, shared.NewUserIdentity(pctx.UID, pctx.CID).String(), ures)
 
 		for _, access := range urs.Access {
 			if access.App == "global" && !access.Admin {
@@ -356,13 +389,58 @@ func (c ApiController) BuildCheckSettings() http.HandlerFunc {
 	}
 }
 
+// BuildGetSettingsStatus reports whether the company's document server is reachable.
+func (c ApiController) BuildGetSettingsStatus() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		pctx, ok := r.Context().Value("X-Pipedrive-App-Context").(request.PipedriveTokenContext)
+		if !ok {
+			rw.WriteHeader(http.StatusForbidden)
+			c.logger.Error("could not extract pipedrive context from the context")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+
+		if status, err := c.checkAdmin(ctx, pctx); err != nil {
+			c.logger.Errorf("could not verify admin access: %s", err.Error())
+			rw.WriteHeader(status)
+			return
+		}
+
+		var status response.DocServerStatusResponse
+		if err := c.client.Call(
+			ctx,
+			c.client.NewRequest(
+				fmt.Sprintf("%s:settings", c.config.Namespace),
+				"SettingsStatusHandler.GetStatus",
+				fmt.Sprint(pctx.CID),
+			),
+			&status,
+			client.WithRequestTimeout(10*time.Second),
+		); err != nil {
+			c.logger.Errorf("could not get document server status: %s", err.Error())
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				rw.WriteHeader(http.StatusRequestTimeout)
+				return
+			}
+
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		rw.Write(status.ToJSON())
+	}
+}
+
 func (c ApiController) BuildGetConfig() http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
 		rw.Header().Set("Content-Type", "application/json")
 
 		query := r.URL.Query()
-		id, filename, key, dealID, dark := strings.TrimSpace(query.Get("id")), strings.TrimSpace(query.Get("name")),
-			strings.TrimSpace(query.Get("key")), strings.TrimSpace(query.Get("deal_id")), query.Get("dark") == "true"
+		id, filename, dark := strings.TrimSpace(query.Get("id")), strings.TrimSpace(query.Get("name")),
+			query.Get("dark") == "true"
 
 		pctx, ok := r.Context().Value("X-Pipedrive-App-Context").(request.PipedriveTokenContext)
 		if !ok {
@@ -383,9 +461,16 @@ func (c ApiController) BuildGetConfig() http.HandlerFunc {
 			return
 		}
 
-		if key == "" {
+		if id == "" {
+			rw.WriteHeader(http.StatusBadRequest)
+			c.logger.Error("could not extract file id from URL Query")
+			return
+		}
+
+		parent, err := parseParent(query, "deal_id")
+		if err != nil {
 			rw.WriteHeader(http.StatusBadRequest)
-			c.logger.Error("could not extract doc key from URL Query")
+			c.logger.Errorf("could not extract file parent from URL Query: %s", err.Error())
 			return
 		}
 
@@ -401,11 +486,10 @@ func (c ApiController) BuildGetConfig() http.HandlerFunc {
 				request.BuildConfigRequest{
 					UID:       pctx.UID,
 					CID:       pctx.CID,
-					Deal:      dealID,
+					Parent:    parent,
 					UserAgent: r.UserAgent(),
 					Filename:  filename,
 					FileID:    id,
-					DocKey:    key,
 					Dark:      dark,
 				},
 			),
@@ -437,3 +521,354 @@ func (c ApiController) BuildGetConfig() http.HandlerFunc {
 		rw.Write(resp.ToJSON())
 	}
 }
+
+func (c ApiController) callBuilder(ctx context.Context, method string, body interface{}, res interface{}, opts ...client.CallOption) (int, error) {
+	if err := c.client.Call(ctx, c.client.NewRequest(
+		fmt.Sprintf("%s:builder", c.config.Namespace), method, body,
+	), res, opts...); err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return http.StatusRequestTimeout, err
+		}
+
+		microErr := response.MicroError{}
+		if err := json.Unmarshal([]byte(err.Error()), &microErr); err != nil {
+			return http.StatusInternalServerError, err
+		}
+
+		if strings.Contains(err.Error(), "could not find document server settings") {
+			return http.StatusPreconditionFailed, err
+		}
+
+		if strings.Contains(err.Error(), "unauthorized file access") {
+			return http.StatusForbidden, err
+		}
+
+		if strings.Contains(err.Error(), "could not find requested file version") {
+			return http.StatusNotFound, err
+		}
+
+		if strings.Contains(err.Error(), "file is no longer available") {
+			return http.StatusGone, err
+		}
+
+		if strings.Contains(err.Error(), "unsupported conversion format") {
+			return http.StatusUnsupportedMediaType, err
+		}
+
+		if strings.Contains(err.Error(), "no active editing session") {
+			return http.StatusNotFound, err
+		}
+
+		if strings.Contains(err.Error(), "no users to disconnect") {
+			return http.StatusBadRequest, err
+		}
+
+		return microErr.Code, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (c ApiController) BuildGetHistory() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+
+		query := r.URL.Query()
+		id, key := strings.TrimSpace(query.Get("id")), strings.TrimSpace(query.Get("key"))
+
+		pctx, ok := r.Context().Value("X-Pipedrive-App-Context").(request.PipedriveTokenContext)
+		if !ok {
+			rw.WriteHeader(http.StatusForbidden)
+			c.logger.Error("could not extract pipedrive context from the context")
+			return
+		}
+
+		if id == "" || key == "" {
+			rw.WriteHeader(http.StatusBadRequest)
+			c.logger.Error("could not extract file id or doc key from URL Query")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 6*time.Second)
+		defer cancel()
+
+		var resp response.BuildHistoryResponse
+		if code, err := c.callBuilder(ctx, "ConfigHandler.BuildHistory", request.BuildHistoryRequest{
+			UID:    pctx.UID,
+			CID:    pctx.CID,
+			FileID: id,
+			DocKey: key,
+		}, &resp); err != nil {
+			c.logger.Errorf("could not build file %s history: %s", id, err.Error())
+			rw.WriteHeader(code)
+			return
+		}
+
+		rw.WriteHeader(http.StatusOK)
+		rw.Write(resp.ToJSON())
+	}
+}
+
+func (c ApiController) BuildGetHistoryData() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+
+		query := r.URL.Query()
+		id, key, filename := strings.TrimSpace(query.Get("id")), strings.TrimSpace(query.Get("key")),
+			strings.TrimSpace(query.Get("name"))
+
+		pctx, ok := r.Context().Value("X-Pipedrive-App-Context").(request.PipedriveTokenContext)
+		if !ok {
+			rw.WriteHeader(http.StatusForbidden)
+			c.logger.Error("could not extract pipedrive context from the context")
+			return
+		}
+
+		version, err := strconv.Atoi(query.Get("version"))
+		if err != nil || version < 1 {
+			rw.WriteHeader(http.StatusBadRequest)
+			c.logger.Error("could not extract a valid version from URL Query")
+			return
+		}
+
+		if id == "" || key == "" || filename == "" {
+			rw.WriteHeader(http.StatusBadRequest)
+			c.logger.Error("could not extract file id, doc key or file name from URL Query")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 6*time.Second)
+		defer cancel()
+
+		var resp response.BuildHistoryDataResponse
+		if code, err := c.callBuilder(ctx, "ConfigHandler.BuildHistoryData", request.BuildHistoryRequest{
+			UID:      pctx.UID,
+			CID:      pctx.CID,
+			FileID:   id,
+			Filename: filename,
+			DocKey:   key,
+			Version:  version,
+		}, &resp); err != nil {
+			c.logger.Errorf("could not build file %s history data: %s", id, err.Error())
+			rw.WriteHeader(code)
+			return
+		}
+
+		rw.WriteHeader(http.StatusOK)
+		rw.Write(resp.ToJSON())
+	}
+}
+
+func (c ApiController) parseConversion(rw http.ResponseWriter, r *http.Request, body *request.ConvertFileRequest, dst interface{}) bool {
+	pctx, ok := r.Context().Value("X-Pipedrive-App-Context").(request.PipedriveTokenContext)
+	if !ok {
+		rw.WriteHeader(http.StatusForbidden)
+		c.logger.Error("could not extract pipedrive context from the context")
+		return false
+	}
+
+	size, err := strconv.ParseInt(r.Header.Get("Content-Length"), 10, 0)
+	if err != nil || (size/100000) > 10 {
+		rw.WriteHeader(http.StatusBadRequest)
+		return false
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		c.logger.Errorf("could not parse conversion request: %s", err.Error())
+		return false
+	}
+
+	body.UID, body.CID = pctx.UID, pctx.CID
+	body.FileID, body.Filename = strings.TrimSpace(body.FileID), strings.TrimSpace(body.Filename)
+	if body.FileID == "" || body.Parent.Validate() != nil || body.Filename == "" {
+		rw.WriteHeader(http.StatusBadRequest)
+		c.logger.Error("could not extract file id, parent or file name from the conversion request")
+		return false
+	}
+
+	if len(body.Filename) > 200 {
+		rw.WriteHeader(http.StatusBadRequest)
+		c.logger.Error("file length is greater than 200")
+		return false
+	}
+
+	return true
+}
+
+func (c ApiController) BuildPostConvert() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+
+		var body request.ConvertFileRequest
+		if !c.parseConversion(rw, r, &body, &body) {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+		defer cancel()
+
+		var resp response.ConvertFileResponse
+		if code, err := c.callBuilder(ctx, "ConfigHandler.ConvertFile", body, &resp, client.WithRetries(0)); err != nil {
+			c.logger.Errorf("could not convert file %s: %s", body.FileID, err.Error())
+			rw.WriteHeader(code)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write(resp.ToJSON())
+	}
+}
+
+func (c ApiController) BuildPostExport() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+
+		var body request.ExportFileRequest
+		if !c.parseConversion(rw, r, &body.ConvertFileRequest, &body) {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+		defer cancel()
+
+		var resp response.ConvertFileResponse
+		if code, err := c.callBuilder(ctx, "ConfigHandler.ExportFile", body, &resp, client.WithRetries(0)); err != nil {
+			c.logger.Errorf("could not export file %s to pdf: %s", body.FileID, err.Error())
+			rw.WriteHeader(code)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write(resp.ToJSON())
+	}
+}
+
+func (c ApiController) checkAdmin(ctx context.Context, pctx request.PipedriveTokenContext) (int, error) {
+	ures, status, err := c.getUser(ctx, shared.NewUserIdentity(pctx.UID, pctx.CID).String())
+	if status != http.StatusOK {
+		return status, err
+	}
+
+	urs, err := c.getProfile(ctx, shared.NewUserIdentity(pctx.UID, pctx.CID).String(), ures)
+	if err != nil {
+		c.logger.Errorf("could not get pipedrive user: %s", err.Error())
+		return http.StatusForbidden, err
+	}
+
+	for _, access := range urs.Access {
+		if access.App == "global" && access.Admin {
+			return http.StatusOK, nil
+		}
+	}
+
+	return http.StatusForbidden, ErrNotAdmin
+}
+
+func (c ApiController) BuildGetAudit() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		pctx, ok := r.Context().Value("X-Pipedrive-App-Context").(request.PipedriveTokenContext)
+		if !ok {
+			rw.WriteHeader(http.StatusForbidden)
+			c.logger.Error("could not extract pipedrive context from the context")
+			return
+		}
+
+		query := r.URL.Query()
+		areq := request.AuditQuery{
+			CompanyID: fmt.Sprint(pctx.CID),
+			UserID:    strings.TrimSpace(query.Get("user_id")),
+			DealID:    strings.TrimSpace(query.Get("deal_id")),
+			Page:      1,
+			Limit:     50,
+		}
+
+		for name, target := range map[string]*time.Time{"from": &areq.From, "to": &areq.To} {
+			if value := strings.TrimSpace(query.Get(name)); value != "" {
+				t, err := time.Parse(time.RFC3339, value)
+				if err != nil {
+					rw.WriteHeader(http.StatusBadRequest)
+					c.logger.Errorf("invalid %s query parameter: %s", name, err.Error())
+					return
+				}
+				*target = t
+			}
+		}
+
+		for name, target := range map[string]*int{"page": &areq.Page, "limit": &areq.Limit} {
+			if value := strings.TrimSpace(query.Get(name)); value != "" {
+				v, err := strconv.Atoi(value)
+				if err != nil || v < 1 {
+					rw.WriteHeader(http.StatusBadRequest)
+					c.logger.Errorf("invalid %s query parameter", name)
+					return
+				}
+				*target = v
+			}
+		}
+
+		if !areq.From.IsZero() && !areq.To.IsZero() && areq.To.Before(areq.From) {
+			rw.WriteHeader(http.StatusBadRequest)
+			c.logger.Error("invalid audit time range")
+			return
+		}
+
+		if areq.Limit > 100 {
+			areq.Limit = 100
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		if status, err := c.checkAdmin(ctx, pctx); err != nil {
+			c.logger.Errorf("could not verify admin access: %s", err.Error())
+			rw.WriteHeader(status)
+			return
+		}
+
+		var resp response.AuditEventsResponse
+		if err := c.client.Call(
+			ctx,
+			c.client.NewRequest(
+				fmt.Sprintf("%s:audit", c.config.Namespace),
+				"AuditSelectHandler.GetEvents",
+				areq,
+			),
+			&resp,
+		); err != nil {
+			c.logger.Errorf("could not get audit events: %s", err.Error())
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				rw.WriteHeader(http.StatusRequestTimeout)
+				return
+			}
+
+			microErr := response.MicroError{}
+			if err := json.Unmarshal([]byte(err.Error()), &microErr); err != nil {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			rw.WriteHeader(microErr.Code)
+			return
+		}
+
+		rw.WriteHeader(http.StatusOK)
+		rw.Write(resp.ToJSON())
+	}
+}
+
+// parseParent extracts the file parent entity from the parent query parameter
+// falling back to a legacy deal id parameter.
+func parseParent(query url.Values, legacy string) (model.Parent, error) {
+	value := strings.TrimSpace(query.Get("parent"))
+	if value == "" {
+		value = strings.TrimSpace(query.Get(legacy))
+	}
+
+	if value == "" {
+		return model.Parent{}, model.ErrInvalidParent
+	}
+
+	return model.ParseParent(value)
+}
@@ -31,6 +31,7 @@ import (
 
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
 	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
@@ -44,6 +45,7 @@ type AuthController struct {
 	pipedriveAPI  pclient.PipedriveApiClient
 	config        *config.ServerConfig
 	credentials   *oauth2.Config
+	audit         shared.AuditEmitter
 	logger        log.Logger
 }
 
@@ -53,6 +55,7 @@ func NewAuthController(
 	pipedriveAPI pclient.PipedriveApiClient,
 	config *config.ServerConfig,
 	credentials *oauth2.Config,
+	audit shared.AuditEmitter,
 	logger log.Logger,
 ) AuthController {
 	return AuthController{
@@ -61,6 +64,7 @@ func NewAuthController(
 		pipedriveAPI:  pipedriveAPI,
 		config:        config,
 		credentials:   credentials,
+		audit:         audit,
 		logger:        logger,
 	}
 }
@@ -114,7 +118,8 @@ func (c AuthController) BuildGetAuth() http.HandlerFunc {
 				fmt.Sprintf("%s:auth", c.config.Namespace),
 				"UserInsertHandler.InsertUser",
 				response.UserResponse{
-					ID:           fmt.Sprint(usr.ID + usr.CompanyID),
+					ID:           shared.NewUserIdentity(usr.ID, usr.CompanyID).String(),
+					CompanyID:    fmt.Sprint(usr.CompanyID),
 					AccessToken:  token.AccessToken,
 					RefreshToken: token.RefreshToken,
 					TokenType:    token.TokenType,
@@ -151,8 +156,8 @@ func (c AuthController) BuildDeleteAuth() http.HandlerFunc {
 		c.logger.Debug("a new uninstall request")
 		var ureq request.UninstallRequest
 
-		len, err := strconv.ParseInt(r.Header.Get("Content-Length"), 10, 0)
-		if err != nil || (len/100000) > 10 {
+		size, err := strconv.ParseInt(r.Header.Get("Content-Length"), 10, 0)
+		if err != nil || (size/100000) > 10 {
 			rw.WriteHeader(http.StatusBadRequest)
 			return
 		}
@@ -169,7 +174,7 @@ func (c AuthController) BuildDeleteAuth() http.HandlerFunc {
 			c.client.NewRequest(
 				fmt.Sprintf("%s:auth", c.config.Namespace),
 				"UserDeleteHandler.DeleteUser",
-				fmt.Sprint(ureq.UserID+ureq.CompanyID),
+				shared.NewUserIdentity(ureq.UserID, ureq.CompanyID).String(),
 			),
 			&res,
 		); err != nil {
@@ -189,7 +194,76 @@ func (c AuthController) BuildDeleteAuth() http.HandlerFunc {
 			return
 		}
 
-		c.logger.Debugf("successfully published delete-auth message for user %s", fmt.Sprint(ureq.UserID+ureq.CompanyID))
+		purge, err := c.purgeCompany(r.Context(), fmt.Sprint(ureq.CompanyID))
+		if err != nil {
+			// Failing the webhook makes Pipedrive deliver it again, so the purge is retried.
+			c.logger.Errorf("could not purge company %d data: %s", ureq.CompanyID, err.Error())
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		c.audit.Emit(request.AuditEvent{
+			Type:      request.AuditAppUninstalled,
+			CompanyID: fmt.Sprint(ureq.CompanyID),
+			UserID:    fmt.Sprint(ureq.UserID),
+			Details: map[string]string{
+				"purged":        strconv.FormatBool(purge.Purged),
+				"users_removed": strconv.Itoa(len(purge.Users)),
+				"settings":      strconv.FormatBool(purge.Settings),
+			},
+		})
+
+		c.logger.Debugf("successfully published delete-auth message for user %s", shared.NewUserIdentity(ureq.UserID, ureq.CompanyID).String())
+		if purge.Purged {
+			c.logger.Infof("company %s data has been purged: %d users, settings removed: %t", purge.CompanyID, len(purge.Users), purge.Settings)
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
 		rw.WriteHeader(http.StatusOK)
+		rw.Write(purge.ToJSON())
+	}
+}
+
+// purgeCompany removes all company data once the company has no active users
+// left. Users whose tokens have been revoked no longer count as active and are
+// removed along with the company settings. Tokens stored before company ids
+// were recorded are attributed to their companies by the migrate command.
+func (c AuthController) purgeCompany(ctx context.Context, cid string) (response.CompanyPurgeResponse, error) {
+	purge := response.CompanyPurgeResponse{CompanyID: cid, Users: []string{}}
+	var users response.CompanyUsersResponse
+	if err := c.client.Call(ctx, c.client.NewRequest(
+		fmt.Sprintf("%s:auth", c.config.Namespace),
+		"UserSelectHandler.GetCompanyUsers", cid,
+	), &users); err != nil {
+		return purge, err
 	}
+
+	if len(users.IDs) > 0 {
+		c.logger.Debugf("company %s still has %d active users. Skipping purge", cid, len(users.IDs))
+		return purge, nil
+	}
+
+	var removed response.CompanyUsersResponse
+	if err := c.client.Call(ctx, c.client.NewRequest(
+		fmt.Sprintf("%s:auth", c.config.Namespace),
+		"UserDeleteHandler.DeleteCompanyUsers", cid,
+	), &removed); err != nil {
+		return purge, err
+	}
+
+	purge.Purged = true
+	if removed.IDs != nil {
+		purge.Users = removed.IDs
+	}
+
+	var res interface{}
+	if err := c.client.Call(ctx, c.client.NewRequest(
+		fmt.Sprintf("%s:settings", c.config.Namespace),
+		"SettingsDeleteHandler.DeleteSettings", cid,
+	), &res); err != nil {
+		return purge, err
+	}
+
+	purge.Settings = true
+	return purge, nil
 }
@@ -19,11 +19,15 @@
 package controller
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,7 +35,10 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/assets"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/adapter"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/port"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
 	pclient "github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
@@ -39,30 +46,66 @@ import (
 	"go-micro.dev/v4/client"
 )
 
+var ErrDownloadLimitExceeded = errors.New("download token has exceeded its downloads")
+
+// downloadClient gives up on stalled transfers so that neither the proxy nor
+// the download slot reserved for them is held for good.
+var downloadClient = &http.Client{
+	Timeout: 5 * time.Minute,
+	Transport: &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   30 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		// Pipedrive redirects to its storage, which is never reached over plain http.
+		if req.URL.Scheme != "https" || len(via) >= 5 {
+			return http.ErrUseLastResponse
+		}
+
+		return nil
+	},
+}
+
 type FileController struct {
-	client     client.Client
-	apiClient  pclient.PipedriveApiClient
-	jwtManager crypto.JwtManager
-	config     *config.ServerConfig
-	onlyoffice *shared.OnlyofficeConfig
-	logger     log.Logger
+	client          client.Client
+	cache           cache.Cache
+	apiClient       pclient.PipedriveApiClient
+	templateService port.TemplateService
+	mergeService    port.MergeService
+	jwtManager      crypto.JwtManager
+	config          *config.ServerConfig
+	onlyoffice      *shared.OnlyofficeConfig
+	download        *shared.DownloadConfig
+	logger          log.Logger
 }
 
 func NewFileController(
 	client client.Client,
+	cache cache.Cache,
 	apiClient pclient.PipedriveApiClient,
+	templateService port.TemplateService,
+	mergeService port.MergeService,
 	jwtManager crypto.JwtManager,
 	config *config.ServerConfig,
 	onlyoffice *shared.OnlyofficeConfig,
+	download *shared.DownloadConfig,
 	logger log.Logger,
 ) FileController {
 	return FileController{
-		client:     client,
-		apiClient:  apiClient,
-		jwtManager: jwtManager,
-		config:     config,
-		onlyoffice: onlyoffice,
-		logger:     logger,
+		client:          client,
+		cache:           cache,
+		apiClient:       apiClient,
+		templateService: templateService,
+		mergeService:    mergeService,
+		jwtManager:      jwtManager,
+		config:          config,
+		onlyoffice:      onlyoffice,
+		download:        download,
+		logger:          logger,
 	}
 }
 
@@ -98,10 +141,11 @@ func (c FileController) BuildGetFile() http.HandlerFunc {
 		rw.Header().Set("Content-Type", "application/json")
 
 		query := r.URL.Query()
-		lang, fileType, dealID, filename := strings.TrimSpace(query.Get("lang")),
-			strings.TrimSpace(query.Get("type")), strings.TrimSpace(query.Get("deal")),
-			strings.TrimSpace(query.Get("filename"))
-		if lang == "" || fileType == "" || dealID == "" || filename == "" {
+		lang, fileType, filename, templateID := strings.TrimSpace(query.Get("lang")),
+			strings.TrimSpace(query.Get("type")), strings.TrimSpace(query.Get("filename")),
+			strings.TrimSpace(query.Get("template_id"))
+		parent, err := parseParent(query, "deal")
+		if lang == "" || fileType == "" || err != nil || filename == "" {
 			rw.WriteHeader(http.StatusBadRequest)
 			return
 		}
@@ -126,49 +170,65 @@ func (c FileController) BuildGetFile() http.HandlerFunc {
 		ctx, cancel := context.WithTimeout(r.Context(), 4*time.Second)
 		defer cancel()
 
-		ures, status := c.getUser(ctx, fmt.Sprint(pctx.UID+pctx.CID))
+		ures, status := c.getUser(ctx, shared.NewUserIdentity(pctx.UID, pctx.CID).String())
 		if status != http.StatusOK {
 			rw.WriteHeader(status)
 			return
 		}
 
-		file, err := assets.Files.Open(fmt.Sprintf("assets/%s/new.%s", lang, fileType))
-		if err != nil {
-			lang = "default"
-			file, err = assets.Files.Open(fmt.Sprintf("assets/%s/new.%s", lang, fileType))
+		token := model.Token{
+			AccessToken:  ures.AccessToken,
+			RefreshToken: ures.AccessToken,
+			TokenType:    ures.TokenType,
+			Scope:        ures.Scope,
+			ApiDomain:    ures.ApiDomain,
+		}
+
+		var file io.ReadCloser
+		if templateID != "" {
+			template, content, err := c.templateService.OpenTemplate(ctx, fmt.Sprint(pctx.CID), templateID)
 			if err != nil {
-				rw.WriteHeader(http.StatusBadRequest)
-				c.logger.Errorf("could not get a new file: %s", err.Error())
+				c.logger.Errorf("could not open template %s: %s", templateID, err.Error())
+				if errors.Is(err, adapter.ErrNoTemplate) || errors.Is(err, adapter.ErrInvalidTemplateID) {
+					rw.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				rw.WriteHeader(http.StatusInternalServerError)
 				return
 			}
 
-			defer file.Close()
-			res, ferr := c.apiClient.CreateFile(ctx, dealID, filename, file, model.Token{
-				AccessToken:  ures.AccessToken,
-				RefreshToken: ures.AccessToken,
-				TokenType:    ures.TokenType,
-				Scope:        ures.Scope,
-				ApiDomain:    ures.ApiDomain,
-			})
+			defer content.Close()
+			if template.Type != fileType {
+				rw.WriteHeader(http.StatusBadRequest)
+				c.logger.Errorf("template %s type %s does not match file type %s", templateID, template.Type, fileType)
+				return
+			}
 
-			if ferr != nil {
+			merged, err := c.mergeTemplate(ctx, content, parent, token)
+			if err != nil {
 				rw.WriteHeader(http.StatusBadRequest)
-				c.logger.Errorf("could not upload a pipedrive file: %s", ferr.Error())
+				c.logger.Errorf("could not merge %s fields into template %s: %s", parent, templateID, err.Error())
 				return
 			}
 
-			rw.Write(res.ToJSON())
-			return
+			file = io.NopCloser(bytes.NewReader(merged))
+		} else {
+			asset, err := assets.Files.Open(fmt.Sprintf("assets/%s/new.%s", lang, fileType))
+			if err != nil {
+				asset, err = assets.Files.Open(fmt.Sprintf("assets/default/new.%s", fileType))
+				if err != nil {
+					rw.WriteHeader(http.StatusBadRequest)
+					c.logger.Errorf("could not get a new file: %s", err.Error())
+					return
+				}
+			}
+
+			file = asset
 		}
 
 		defer file.Close()
-		res, ferr := c.apiClient.CreateFile(ctx, dealID, filename, file, model.Token{
-			AccessToken:  ures.AccessToken,
-			RefreshToken: ures.AccessToken,
-			TokenType:    ures.TokenType,
-			Scope:        ures.Scope,
-			ApiDomain:    ures.ApiDomain,
-		})
+		res, ferr := c.apiClient.CreateFile(ctx, parent, filename, file, token)
 
 		if ferr != nil {
 			rw.WriteHeader(http.StatusBadRequest)
@@ -184,15 +244,31 @@ func (c FileController) BuildGetDownloadUrl() http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
 		rw.Header().Set("Content-Type", "plain/text")
 		query := r.URL.Query()
-		domain, fileID := query.Get("domain"), query.Get("file_id")
+		domain, fileID := query.Get("domain"), strings.TrimSpace(query.Get("file_id"))
+		if !shared.IsPipedriveDomain(domain) {
+			c.logger.Errorf("refusing to build a download url for domain %s", domain)
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// The caller's token is forwarded, so the file id must not reach any other path.
+		if id, err := strconv.Atoi(fileID); err != nil || id <= 0 {
+			c.logger.Errorf("refusing to build a download url for file %s", fileID)
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
 
 		client := &http.Client{
+			Timeout: 10 * time.Second,
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				return http.ErrUseLastResponse
 			},
 		}
 
-		dreq, _ := http.NewRequest("GET", fmt.Sprintf("%s/files/%s/download", strings.TrimSuffix(domain, "/"), fileID), nil)
+		dreq, _ := http.NewRequestWithContext(
+			r.Context(), "GET",
+			fmt.Sprintf("%s/files/%s/download", strings.TrimSuffix(domain, "/"), url.PathEscape(fileID)), nil,
+		)
 		dreq.Header.Add("Authorization", r.Header.Get("Authorization"))
 		resp, err := client.Do(dreq)
 		if err != nil {
@@ -214,3 +290,115 @@ func (c FileController) BuildGetDownloadUrl() http.HandlerFunc {
 		rw.Write([]byte(resp.Header.Get("Location")))
 	}
 }
+
+func downloadKey(token shared.DownloadToken) string {
+	return fmt.Sprintf("download-%s", token.ID)
+}
+
+// reserveDownload counts a download against its token across replicas and reports
+// whether the token still allows it. Reservations of failed downloads are released.
+func (c FileController) reserveDownload(ctx context.Context, token shared.DownloadToken) (int, error) {
+	limit := c.onlyoffice.Onlyoffice.Builder.AllowedDownloads
+	if limit <= 0 {
+		return http.StatusOK, nil
+	}
+
+	count, err := c.cache.Increment(ctx, downloadKey(token), 1, time.Until(time.Unix(token.ExpiresAt, 0)))
+	if err != nil {
+		return http.StatusServiceUnavailable, err
+	}
+
+	if count > int64(limit) {
+		c.releaseDownload(token)
+		return http.StatusTooManyRequests, ErrDownloadLimitExceeded
+	}
+
+	return http.StatusOK, nil
+}
+
+func (c FileController) releaseDownload(token shared.DownloadToken) {
+	if c.onlyoffice.Onlyoffice.Builder.AllowedDownloads <= 0 {
+		return
+	}
+
+	// Requests may be gone by now, yet their reservations must still be returned.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := c.cache.Increment(ctx, downloadKey(token), -1, time.Until(time.Unix(token.ExpiresAt, 0))); err != nil {
+		c.logger.Warnf("could not release download %s. Reason: %s", token.ID, err.Error())
+	}
+}
+
+func (c FileController) BuildGetProxyDownload() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		token, err := shared.VerifyDownloadToken(c.jwtManager, c.download.Download.Secret, r.URL.Query().Get("token"))
+		if err != nil {
+			c.logger.Errorf("invalid download token. Reason: %s", err.Error())
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if status, err := c.reserveDownload(r.Context(), token); err != nil {
+			c.logger.Warnf("could not use download token %s: %s", token.ID, err.Error())
+			rw.WriteHeader(status)
+			return
+		}
+
+		streamed := false
+		defer func() {
+			if !streamed {
+				c.releaseDownload(token)
+			}
+		}()
+
+		ures, status := c.getUser(r.Context(), shared.NewUserIdentity(token.UserID, token.CompanyID).String())
+		if status != http.StatusOK {
+			rw.WriteHeader(status)
+			return
+		}
+
+		if !shared.IsPipedriveDomain(ures.ApiDomain) {
+			c.logger.Errorf("refusing to download file %s from domain %s", token.FileID, ures.ApiDomain)
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		dreq, err := http.NewRequestWithContext(
+			r.Context(), "GET",
+			fmt.Sprintf("%s/files/%s/download", strings.TrimSuffix(ures.ApiDomain, "/"), url.PathEscape(token.FileID)), nil,
+		)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		dreq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ures.AccessToken))
+		resp, err := downloadClient.Do(dreq)
+		if err != nil {
+			c.logger.Errorf("could not download file %s: %s", token.FileID, err.Error())
+			rw.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			c.logger.Errorf("unexpected status code while downloading file %s: %d", token.FileID, resp.StatusCode)
+			rw.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		for _, header := range []string{"Content-Type", "Content-Length", "Content-Disposition"} {
+			if val := resp.Header.Get(header); val != "" {
+				rw.Header().Set(header, val)
+			}
+		}
+
+		if _, err := io.Copy(rw, resp.Body); err != nil {
+			c.logger.Errorf("could not stream file %s: %s", token.FileID, err.Error())
+			return
+		}
+
+		streamed = true
+	}
+}
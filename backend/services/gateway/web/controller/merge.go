@@ -0,0 +1,108 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
+)
+
+var ErrUnsupportedMergeParent = errors.New("templates can not be filled for this parent")
+
+// buildMergeFields collects placeholder values of the file parent. Deals
+// contribute their person and organization, persons and organizations only
+// themselves. Other parents have no fields to fill templates with.
+func (c FileController) buildMergeFields(ctx context.Context, parent model.Parent, token model.Token) (domain.MergeFields, error) {
+	var (
+		deal   model.Deal
+		person model.Person
+		org    model.Organization
+		err    error
+	)
+
+	switch parent.Type {
+	case model.ParentDeal:
+		if deal, err = c.apiClient.GetDeal(ctx, parent.ID, token); err != nil {
+			return nil, err
+		}
+
+		if deal.Person.ID != 0 {
+			if person, err = c.apiClient.GetPerson(ctx, deal.Person.ID, token); err != nil {
+				return nil, err
+			}
+		}
+
+		if deal.Organization.ID != 0 {
+			if org, err = c.apiClient.GetOrganization(ctx, deal.Organization.ID, token); err != nil {
+				return nil, err
+			}
+		}
+	case model.ParentPerson:
+		id, _ := strconv.Atoi(parent.ID)
+		if person, err = c.apiClient.GetPerson(ctx, id, token); err != nil {
+			return nil, err
+		}
+	case model.ParentOrganization:
+		id, _ := strconv.Atoi(parent.ID)
+		if org, err = c.apiClient.GetOrganization(ctx, id, token); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedMergeParent
+	}
+
+	value := strconv.FormatFloat(deal.Value, 'f', -1, 64)
+	if deal.Currency != "" {
+		value = fmt.Sprintf("%s %s", value, deal.Currency)
+	}
+
+	return domain.MergeFields{
+		"deal.id":                  fmt.Sprint(deal.ID),
+		"deal.title":               deal.Title,
+		"deal.value":               value,
+		"deal.currency":            deal.Currency,
+		"deal.status":              deal.Status,
+		"deal.expected_close_date": deal.ExpectedCloseDate,
+		"person.name":              person.Name,
+		"person.email":             person.PrimaryEmail(),
+		"person.phone":             person.PrimaryPhone(),
+		"org.name":                 org.Name,
+		"org.address":              org.Address,
+	}, nil
+}
+
+func (c FileController) mergeTemplate(ctx context.Context, content io.Reader, parent model.Parent, token model.Token) ([]byte, error) {
+	buffer, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := c.buildMergeFields(ctx, parent, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.mergeService.Merge(ctx, buffer, fields)
+}
@@ -0,0 +1,94 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+)
+
+// buildSessionCommand builds an admin-only handler that runs a builder
+// session command against the file passed in the id query parameter.
+func (c ApiController) buildSessionCommand(method string) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		pctx, ok := r.Context().Value("X-Pipedrive-App-Context").(request.PipedriveTokenContext)
+		if !ok {
+			rw.WriteHeader(http.StatusForbidden)
+			c.logger.Error("could not extract pipedrive context from the context")
+			return
+		}
+
+		query := r.URL.Query()
+		fid := strings.TrimSpace(query.Get("id"))
+		if fid == "" {
+			rw.WriteHeader(http.StatusBadRequest)
+			c.logger.Error("could not extract file id from URL Query")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+
+		if status, err := c.checkAdmin(ctx, pctx); err != nil {
+			rw.WriteHeader(status)
+			c.logger.Errorf("could not run %s on file %s: %s", method, fid, err.Error())
+			return
+		}
+
+		var users []string
+		for _, user := range query["user"] {
+			if user = strings.TrimSpace(user); user != "" {
+				users = append(users, user)
+			}
+		}
+
+		var res response.SessionResponse
+		if code, err := c.callBuilder(ctx, method, request.SessionRequest{
+			UID:    pctx.UID,
+			CID:    pctx.CID,
+			FileID: fid,
+			Users:  users,
+		}, &res); err != nil {
+			c.logger.Errorf("could not run %s on file %s: %s", method, fid, err.Error())
+			rw.WriteHeader(code)
+			return
+		}
+
+		rw.WriteHeader(http.StatusOK)
+		rw.Write(res.ToJSON())
+	}
+}
+
+func (c ApiController) BuildGetSession() http.HandlerFunc {
+	return c.buildSessionCommand("ConfigHandler.GetSession")
+}
+
+func (c ApiController) BuildPostSessionForceSave() http.HandlerFunc {
+	return c.buildSessionCommand("ConfigHandler.ForceSaveSession")
+}
+
+func (c ApiController) BuildPostSessionDisconnect() http.HandlerFunc {
+	return c.buildSessionCommand("ConfigHandler.DisconnectSession")
+}
@@ -0,0 +1,164 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/adapter"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/service"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+)
+
+func toTemplateResponse(template domain.Template) response.TemplateResponse {
+	return response.TemplateResponse{
+		ID:        template.ID,
+		Name:      template.Name,
+		Type:      template.Type,
+		Size:      template.Size,
+		CreatedAt: template.CreatedAt,
+	}
+}
+
+func (c ApiController) BuildGetTemplates() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		pctx, ok := r.Context().Value("X-Pipedrive-App-Context").(request.PipedriveTokenContext)
+		if !ok {
+			rw.WriteHeader(http.StatusForbidden)
+			c.logger.Error("could not extract pipedrive context from the context")
+			return
+		}
+
+		templates, err := c.templateService.GetTemplates(r.Context(), fmt.Sprint(pctx.CID))
+		if err != nil {
+			rw.WriteHeader(http.StatusInternalServerError)
+			c.logger.Errorf("could not get company %d templates: %s", pctx.CID, err.Error())
+			return
+		}
+
+		resp := make(response.TemplatesResponse, 0, len(templates))
+		for _, template := range templates {
+			resp = append(resp, toTemplateResponse(template))
+		}
+
+		rw.WriteHeader(http.StatusOK)
+		rw.Write(resp.ToJSON())
+	}
+}
+
+func (c ApiController) BuildPostTemplate() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		pctx, ok := r.Context().Value("X-Pipedrive-App-Context").(request.PipedriveTokenContext)
+		if !ok {
+			rw.WriteHeader(http.StatusForbidden)
+			c.logger.Error("could not extract pipedrive context from the context")
+			return
+		}
+
+		if status, err := c.checkAdmin(r.Context(), pctx); err != nil {
+			rw.WriteHeader(status)
+			c.logger.Errorf("could not upload a template: %s", err.Error())
+			return
+		}
+
+		r.Body = http.MaxBytesReader(rw, r.Body, c.templates.Templates.MaxSize+1<<20)
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			c.logger.Errorf("could not extract template file: %s", err.Error())
+			return
+		}
+		defer file.Close()
+
+		name := strings.TrimSpace(r.FormValue("name"))
+		ext := filepath.Ext(header.Filename)
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(header.Filename), ext)
+		}
+
+		template, err := c.templateService.CreateTemplate(r.Context(), domain.Template{
+			CompanyID: fmt.Sprint(pctx.CID),
+			Name:      name,
+			Type:      strings.TrimPrefix(strings.ToLower(ext), "."),
+			Size:      header.Size,
+			CreatedBy: fmt.Sprint(pctx.UID),
+		}, file)
+		if err != nil {
+			c.logger.Errorf("could not create a template: %s", err.Error())
+			var fieldErr *domain.InvalidModelFieldError
+			switch {
+			case errors.Is(err, service.ErrTemplateTooLarge):
+				rw.WriteHeader(http.StatusRequestEntityTooLarge)
+			case errors.Is(err, service.ErrInvalidTemplateContent), errors.As(err, &fieldErr):
+				rw.WriteHeader(http.StatusBadRequest)
+			default:
+				rw.WriteHeader(http.StatusInternalServerError)
+			}
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write(toTemplateResponse(template).ToJSON())
+	}
+}
+
+func (c ApiController) BuildDeleteTemplate() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		pctx, ok := r.Context().Value("X-Pipedrive-App-Context").(request.PipedriveTokenContext)
+		if !ok {
+			rw.WriteHeader(http.StatusForbidden)
+			c.logger.Error("could not extract pipedrive context from the context")
+			return
+		}
+
+		id := strings.TrimSpace(r.URL.Query().Get("id"))
+		if id == "" {
+			rw.WriteHeader(http.StatusBadRequest)
+			c.logger.Error("could not extract template id from URL Query")
+			return
+		}
+
+		if status, err := c.checkAdmin(r.Context(), pctx); err != nil {
+			rw.WriteHeader(status)
+			c.logger.Errorf("could not remove template %s: %s", id, err.Error())
+			return
+		}
+
+		if err := c.templateService.RemoveTemplate(r.Context(), fmt.Sprint(pctx.CID), id); err != nil {
+			c.logger.Errorf("could not remove template %s: %s", id, err.Error())
+			if errors.Is(err, adapter.ErrNoTemplate) || errors.Is(err, adapter.ErrInvalidTemplateID) {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		rw.WriteHeader(http.StatusOK)
+	}
+}
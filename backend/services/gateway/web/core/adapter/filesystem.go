@@ -0,0 +1,225 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/port"
+	"github.com/google/uuid"
+)
+
+type filesystemTemplateAdapter struct {
+	mu   sync.RWMutex
+	root string
+}
+
+func NewFilesystemTemplateAdapter(root string) port.TemplateServiceAdapter {
+	return &filesystemTemplateAdapter{
+		root: root,
+	}
+}
+
+func (f *filesystemTemplateAdapter) companyDir(cid string) (string, error) {
+	cid = strings.TrimSpace(cid)
+	if cid == "" || filepath.Base(cid) != cid || strings.HasPrefix(cid, ".") {
+		return "", ErrInvalidCompanyID
+	}
+
+	return filepath.Join(f.root, cid), nil
+}
+
+func (f *filesystemTemplateAdapter) paths(cid, id string) (string, string, error) {
+	dir, err := f.companyDir(cid)
+	if err != nil {
+		return "", "", err
+	}
+
+	if _, err := uuid.Parse(id); err != nil {
+		return "", "", ErrInvalidTemplateID
+	}
+
+	return filepath.Join(dir, id+".json"), filepath.Join(dir, id+".bin"), nil
+}
+
+func (f *filesystemTemplateAdapter) InsertTemplate(ctx context.Context, template domain.Template, content io.Reader) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	meta, data, err := f.paths(template.CompanyID, template.ID)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(data), 0o750); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(data, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		os.Remove(data)
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(data)
+		return err
+	}
+
+	if err := os.WriteFile(meta, template.ToJSON(), 0o640); err != nil {
+		os.Remove(data)
+		return err
+	}
+
+	return nil
+}
+
+func (f *filesystemTemplateAdapter) SelectTemplates(ctx context.Context, cid string) ([]domain.Template, error) {
+	dir, err := f.companyDir(cid)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	templates := make([]domain.Template, 0)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return templates, nil
+		}
+
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		buffer, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var template domain.Template
+		if err := json.Unmarshal(buffer, &template); err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, template)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].CreatedAt.Before(templates[j].CreatedAt)
+	})
+
+	return templates, nil
+}
+
+func (f *filesystemTemplateAdapter) SelectTemplate(ctx context.Context, cid, id string) (domain.Template, error) {
+	var template domain.Template
+	meta, _, err := f.paths(cid, id)
+	if err != nil {
+		return template, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	buffer, err := os.ReadFile(meta)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return template, ErrNoTemplate
+		}
+
+		return template, err
+	}
+
+	if err := json.Unmarshal(buffer, &template); err != nil {
+		return template, err
+	}
+
+	return template, nil
+}
+
+func (f *filesystemTemplateAdapter) OpenTemplate(ctx context.Context, template domain.Template) (io.ReadCloser, error) {
+	_, data, err := f.paths(template.CompanyID, template.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	file, err := os.Open(data)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNoTemplate
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (f *filesystemTemplateAdapter) DeleteTemplate(ctx context.Context, cid, id string) error {
+	meta, data, err := f.paths(cid, id)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.Remove(meta); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrNoTemplate
+		}
+
+		return err
+	}
+
+	if err := os.Remove(data); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var template = domain.Template{
+	ID:        "7c5c2f4e-2b1e-4d6a-9f0c-8d1e3a4b5c6d",
+	CompanyID: "mock",
+	Name:      "mock",
+	Type:      "docx",
+	Size:      4,
+	CreatedBy: "mock",
+	CreatedAt: time.Now().UTC().Truncate(time.Second),
+}
+
+func TestFilesystemAdapter(t *testing.T) {
+	adapter := NewFilesystemTemplateAdapter(t.TempDir())
+
+	t.Run("save template", func(t *testing.T) {
+		assert.NoError(t, adapter.InsertTemplate(context.Background(), template, bytes.NewReader([]byte("PK\x03\x04"))))
+	})
+
+	t.Run("save template with invalid company id", func(t *testing.T) {
+		invalid := template
+		invalid.CompanyID = "../mock"
+		assert.Error(t, adapter.InsertTemplate(context.Background(), invalid, bytes.NewReader([]byte("PK\x03\x04"))))
+	})
+
+	t.Run("get company templates", func(t *testing.T) {
+		templates, err := adapter.SelectTemplates(context.Background(), "mock")
+		assert.NoError(t, err)
+		assert.Len(t, templates, 1)
+		assert.Equal(t, template.ID, templates[0].ID)
+	})
+
+	t.Run("get another company templates", func(t *testing.T) {
+		templates, err := adapter.SelectTemplates(context.Background(), "another")
+		assert.NoError(t, err)
+		assert.Empty(t, templates)
+	})
+
+	t.Run("open template", func(t *testing.T) {
+		tmpl, err := adapter.SelectTemplate(context.Background(), "mock", template.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, template.Name, tmpl.Name)
+
+		file, err := adapter.OpenTemplate(context.Background(), tmpl)
+		assert.NoError(t, err)
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("PK\x03\x04"), content)
+	})
+
+	t.Run("get template with invalid id", func(t *testing.T) {
+		_, err := adapter.SelectTemplate(context.Background(), "mock", "../mock")
+		assert.ErrorIs(t, err, ErrInvalidTemplateID)
+	})
+
+	t.Run("delete template", func(t *testing.T) {
+		assert.NoError(t, adapter.DeleteTemplate(context.Background(), "mock", template.ID))
+	})
+
+	t.Run("get deleted template", func(t *testing.T) {
+		_, err := adapter.SelectTemplate(context.Background(), "mock", template.ID)
+		assert.ErrorIs(t, err, ErrNoTemplate)
+	})
+}
@@ -0,0 +1,211 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/port"
+	"github.com/kamva/mgm/v3"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const templateBucket = "templates"
+
+type templateMetadata struct {
+	CompanyID string `bson:"company_id"`
+	Name      string `bson:"name"`
+	Type      string `bson:"type"`
+	CreatedBy string `bson:"created_by"`
+}
+
+type templateFile struct {
+	ID         string           `bson:"_id"`
+	Length     int64            `bson:"length"`
+	UploadDate time.Time        `bson:"uploadDate"`
+	Metadata   templateMetadata `bson:"metadata"`
+}
+
+func (t templateFile) toDomain() domain.Template {
+	return domain.Template{
+		ID:        t.ID,
+		CompanyID: t.Metadata.CompanyID,
+		Name:      t.Metadata.Name,
+		Type:      t.Metadata.Type,
+		Size:      t.Length,
+		CreatedBy: t.Metadata.CreatedBy,
+		CreatedAt: t.UploadDate,
+	}
+}
+
+type gridfsTemplateAdapter struct {
+	timeout time.Duration
+}
+
+func NewGridFSTemplateAdapter(url string) port.TemplateServiceAdapter {
+	if err := mgm.SetDefaultConfig(
+		&mgm.Config{CtxTimeout: 3 * time.Second}, "pipedrive",
+		options.Client().ApplyURI(url),
+	); err != nil {
+		log.Fatalf("mongo initialization error: %s", err.Error())
+	}
+
+	return &gridfsTemplateAdapter{
+		timeout: 30 * time.Second,
+	}
+}
+
+func (g *gridfsTemplateAdapter) bucket() (*gridfs.Bucket, error) {
+	_, _, db, err := mgm.DefaultConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	return gridfs.NewBucket(db, options.GridFSBucket().SetName(templateBucket))
+}
+
+func (g *gridfsTemplateAdapter) InsertTemplate(ctx context.Context, template domain.Template, content io.Reader) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	bucket, err := g.bucket()
+	if err != nil {
+		return err
+	}
+
+	if err := bucket.SetWriteDeadline(time.Now().Add(g.timeout)); err != nil {
+		return err
+	}
+
+	return bucket.UploadFromStreamWithID(
+		template.ID, template.Filename(), content,
+		options.GridFSUpload().SetMetadata(templateMetadata{
+			CompanyID: template.CompanyID,
+			Name:      template.Name,
+			Type:      template.Type,
+			CreatedBy: template.CreatedBy,
+		}),
+	)
+}
+
+func (g *gridfsTemplateAdapter) SelectTemplates(ctx context.Context, cid string) ([]domain.Template, error) {
+	cid = strings.TrimSpace(cid)
+	if cid == "" {
+		return nil, ErrInvalidCompanyID
+	}
+
+	bucket, err := g.bucket()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := bucket.FindContext(ctx, bson.M{"metadata.company_id": cid},
+		options.GridFSFind().SetSort(bson.M{"uploadDate": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []templateFile
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+
+	templates := make([]domain.Template, 0, len(files))
+	for _, file := range files {
+		templates = append(templates, file.toDomain())
+	}
+
+	return templates, nil
+}
+
+func (g *gridfsTemplateAdapter) SelectTemplate(ctx context.Context, cid, id string) (domain.Template, error) {
+	cid, id = strings.TrimSpace(cid), strings.TrimSpace(id)
+	if cid == "" {
+		return domain.Template{}, ErrInvalidCompanyID
+	}
+
+	if id == "" {
+		return domain.Template{}, ErrInvalidTemplateID
+	}
+
+	bucket, err := g.bucket()
+	if err != nil {
+		return domain.Template{}, err
+	}
+
+	var file templateFile
+	if err := bucket.GetFilesCollection().FindOne(ctx, bson.M{
+		"_id":                 id,
+		"metadata.company_id": cid,
+	}).Decode(&file); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Template{}, ErrNoTemplate
+		}
+
+		return domain.Template{}, err
+	}
+
+	return file.toDomain(), nil
+}
+
+func (g *gridfsTemplateAdapter) OpenTemplate(ctx context.Context, template domain.Template) (io.ReadCloser, error) {
+	bucket, err := g.bucket()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bucket.SetReadDeadline(time.Now().Add(g.timeout)); err != nil {
+		return nil, err
+	}
+
+	stream, err := bucket.OpenDownloadStream(template.ID)
+	if err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, ErrNoTemplate
+		}
+
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+func (g *gridfsTemplateAdapter) DeleteTemplate(ctx context.Context, cid, id string) error {
+	if _, err := g.SelectTemplate(ctx, cid, id); err != nil {
+		return err
+	}
+
+	bucket, err := g.bucket()
+	if err != nil {
+		return err
+	}
+
+	return bucket.DeleteContext(ctx, id)
+}
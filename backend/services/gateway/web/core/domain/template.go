@@ -0,0 +1,101 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var templateTypes = map[string]bool{
+	"docx": true,
+	"xlsx": true,
+	"pptx": true,
+}
+
+type Template struct {
+	ID        string    `json:"id" mapstructure:"id"`
+	CompanyID string    `json:"company_id" mapstructure:"company_id"`
+	Name      string    `json:"name" mapstructure:"name"`
+	Type      string    `json:"type" mapstructure:"type"`
+	Size      int64     `json:"size" mapstructure:"size"`
+	CreatedBy string    `json:"created_by" mapstructure:"created_by"`
+	CreatedAt time.Time `json:"created_at" mapstructure:"created_at"`
+}
+
+func (t Template) ToJSON() []byte {
+	buf, _ := json.Marshal(t)
+	return buf
+}
+
+func (t Template) Filename() string {
+	return t.Name + "." + t.Type
+}
+
+func (t *Template) Validate() error {
+	t.ID = strings.TrimSpace(t.ID)
+	t.CompanyID = strings.TrimSpace(t.CompanyID)
+	t.Name = strings.TrimSpace(t.Name)
+	t.Type = strings.ToLower(strings.TrimSpace(t.Type))
+
+	if _, err := uuid.Parse(t.ID); err != nil {
+		return &InvalidModelFieldError{
+			Model:  "Template",
+			Field:  "ID",
+			Reason: "Should be a valid uuid",
+		}
+	}
+
+	if t.CompanyID == "" || strings.ContainsAny(t.CompanyID, `/\.`) {
+		return &InvalidModelFieldError{
+			Model:  "Template",
+			Field:  "CompanyID",
+			Reason: "Should be a valid company id",
+		}
+	}
+
+	if t.Name == "" || len(t.Name) > 190 {
+		return &InvalidModelFieldError{
+			Model:  "Template",
+			Field:  "Name",
+			Reason: "Should not be empty or longer than 190 characters",
+		}
+	}
+
+	if !templateTypes[t.Type] {
+		return &InvalidModelFieldError{
+			Model:  "Template",
+			Field:  "Type",
+			Reason: "Should be one of docx, xlsx or pptx",
+		}
+	}
+
+	if t.Size <= 0 {
+		return &InvalidModelFieldError{
+			Model:  "Template",
+			Field:  "Size",
+			Reason: "Should be greater than zero",
+		}
+	}
+
+	return nil
+}
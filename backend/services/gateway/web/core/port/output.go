@@ -0,0 +1,34 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package port
+
+import (
+	"context"
+	"io"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/domain"
+)
+
+type TemplateServiceAdapter interface {
+	InsertTemplate(ctx context.Context, template domain.Template, content io.Reader) error
+	SelectTemplates(ctx context.Context, cid string) ([]domain.Template, error)
+	SelectTemplate(ctx context.Context, cid, id string) (domain.Template, error)
+	OpenTemplate(ctx context.Context, template domain.Template) (io.ReadCloser, error)
+	DeleteTemplate(ctx context.Context, cid, id string) error
+}
@@ -0,0 +1,245 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/port"
+)
+
+// Uncompressed size limit of a single xml part. Guards against zip bombs.
+const maxPartSize = 64 << 20
+
+var (
+	// Parts of docx, xlsx and pptx packages which may contain user text.
+	mergePartPattern = regexp.MustCompile(
+		`^(word/(document|header\d*|footer\d*|footnotes|endnotes)|` +
+			`xl/(sharedStrings|worksheets/sheet\d+)|` +
+			`ppt/(slides/slide\d+|notesSlides/notesSlide\d+))\.xml$`,
+	)
+	// Text runs of WordprocessingML, DrawingML and SpreadsheetML.
+	mergeTextPattern = regexp.MustCompile(`<(?:w:t|a:t|t)(?:\s[^>]*)?>([^<]*)</(?:w:t|a:t|t)>`)
+	// Placeholders never span paragraphs, shared strings or inline strings.
+	mergeBoundaryPattern = regexp.MustCompile(`</(?:w:p|a:p|si|is)>`)
+	mergeFieldPattern    = regexp.MustCompile(`\{\{\s*([a-z]+\.[a-z_]+)\s*\}\}`)
+)
+
+type mergeService struct {
+	logger plog.Logger
+}
+
+func NewMergeService(logger plog.Logger) port.MergeService {
+	return mergeService{
+		logger: logger,
+	}
+}
+
+func (s mergeService) Merge(ctx context.Context, content []byte, fields domain.MergeFields) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, ErrInvalidTemplateContent
+	}
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, file := range reader.File {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if !mergePartPattern.MatchString(file.Name) {
+			if err := writer.Copy(file); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		part, err := readPart(file)
+		if err != nil {
+			return nil, err
+		}
+
+		merged, changed := mergePart(part, fields)
+		if !changed {
+			if err := writer.Copy(file); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		s.logger.Debugf("merged deal fields into %s", file.Name)
+		w, err := writer.CreateHeader(&zip.FileHeader{
+			Name:     file.Name,
+			Method:   zip.Deflate,
+			Modified: file.Modified,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := w.Write(merged); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func readPart(file *zip.File) ([]byte, error) {
+	if file.UncompressedSize64 > maxPartSize {
+		return nil, ErrMergePartTooLarge
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	part, err := io.ReadAll(io.LimitReader(rc, maxPartSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(part) > maxPartSize {
+		return nil, ErrMergePartTooLarge
+	}
+
+	return part, nil
+}
+
+// mergePart replaces placeholders in the text runs of an xml part. Editors
+// tend to split a single placeholder across several runs, so the runs of a
+// paragraph are joined before matching and the replacement is written back
+// into the first run the placeholder touches.
+func mergePart(part []byte, fields domain.MergeFields) ([]byte, bool) {
+	runs := mergeTextPattern.FindAllSubmatchIndex(part, -1)
+	if len(runs) == 0 {
+		return part, false
+	}
+
+	boundaries := mergeBoundaryPattern.FindAllIndex(part, -1)
+	texts := make([]string, len(runs))
+	for i, run := range runs {
+		texts[i] = string(part[run[2]:run[3]])
+	}
+
+	changed := false
+	for start := 0; start < len(runs); {
+		end := start + 1
+		for end < len(runs) && !crossesBoundary(boundaries, runs[end-1][1], runs[end][0]) {
+			end++
+		}
+
+		if mergeParagraph(texts[start:end], fields) {
+			changed = true
+		}
+
+		start = end
+	}
+
+	if !changed {
+		return part, false
+	}
+
+	var buffer bytes.Buffer
+	buffer.Grow(len(part))
+	last := 0
+	for i, run := range runs {
+		buffer.Write(part[last:run[2]])
+		buffer.WriteString(texts[i])
+		last = run[3]
+	}
+	buffer.Write(part[last:])
+
+	return buffer.Bytes(), true
+}
+
+func crossesBoundary(boundaries [][]int, from, to int) bool {
+	i := sort.Search(len(boundaries), func(i int) bool {
+		return boundaries[i][0] >= from
+	})
+
+	return i < len(boundaries) && boundaries[i][0] < to
+}
+
+func mergeParagraph(texts []string, fields domain.MergeFields) bool {
+	offsets := make([]int, len(texts))
+	var joined strings.Builder
+	for i, text := range texts {
+		offsets[i] = joined.Len()
+		joined.WriteString(text)
+	}
+
+	matches := mergeFieldPattern.FindAllStringSubmatchIndex(joined.String(), -1)
+	if len(matches) == 0 {
+		return false
+	}
+
+	locate := func(pos int) int {
+		return sort.Search(len(offsets), func(i int) bool {
+			return offsets[i] > pos
+		}) - 1
+	}
+
+	changed := false
+	// Walking backwards keeps the offsets of earlier placeholders intact.
+	for m := len(matches) - 1; m >= 0; m-- {
+		match := matches[m]
+		value, ok := fields[joined.String()[match[2]:match[3]]]
+		if !ok {
+			continue
+		}
+
+		var escaped strings.Builder
+		xml.EscapeText(&escaped, []byte(value))
+
+		first, last := locate(match[0]), locate(match[1]-1)
+		if first == last {
+			text := texts[first]
+			texts[first] = text[:match[0]-offsets[first]] + escaped.String() + text[match[1]-offsets[first]:]
+		} else {
+			texts[first] = texts[first][:match[0]-offsets[first]] + escaped.String()
+			for i := first + 1; i < last; i++ {
+				texts[i] = ""
+			}
+			texts[last] = texts[last][match[1]-offsets[last]:]
+		}
+
+		changed = true
+	}
+
+	return changed
+}
@@ -0,0 +1,134 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildPackage(t *testing.T, parts map[string]string) []byte {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range parts {
+		w, err := writer.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func readPackagePart(t *testing.T, content []byte, name string) string {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+
+	file, err := reader.Open(name)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	part, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	return string(part)
+}
+
+func TestMergeService(t *testing.T) {
+	service := NewMergeService(plog.NewEmptyLogger())
+	fields := domain.MergeFields{
+		"deal.title":  "Deal & Co",
+		"deal.value":  "1500 EUR",
+		"person.name": "John",
+		"org.address": "",
+	}
+
+	t.Run("merge a single run", func(t *testing.T) {
+		content := buildPackage(t, map[string]string{
+			"word/document.xml": `<w:p><w:r><w:t>Title: {{deal.title}}</w:t></w:r></w:p>`,
+		})
+
+		merged, err := service.Merge(context.Background(), content, fields)
+		assert.NoError(t, err)
+		assert.Equal(t, `<w:p><w:r><w:t>Title: Deal &amp; Co</w:t></w:r></w:p>`,
+			readPackagePart(t, merged, "word/document.xml"))
+	})
+
+	t.Run("merge split runs", func(t *testing.T) {
+		content := buildPackage(t, map[string]string{
+			"word/document.xml": `<w:p><w:r><w:t>{{deal.</w:t></w:r><w:r><w:t xml:space="preserve">val</w:t></w:r>` +
+				`<w:r><w:t>ue}} for {{person.name}}</w:t></w:r></w:p>`,
+		})
+
+		merged, err := service.Merge(context.Background(), content, fields)
+		assert.NoError(t, err)
+		assert.Equal(t, `<w:p><w:r><w:t>1500 EUR</w:t></w:r><w:r><w:t xml:space="preserve"></w:t></w:r>`+
+			`<w:r><w:t> for John</w:t></w:r></w:p>`, readPackagePart(t, merged, "word/document.xml"))
+	})
+
+	t.Run("do not merge across paragraphs", func(t *testing.T) {
+		part := `<w:p><w:r><w:t>{{deal.</w:t></w:r></w:p><w:p><w:r><w:t>title}}</w:t></w:r></w:p>`
+		content := buildPackage(t, map[string]string{
+			"word/document.xml": part,
+		})
+
+		merged, err := service.Merge(context.Background(), content, fields)
+		assert.NoError(t, err)
+		assert.Equal(t, part, readPackagePart(t, merged, "word/document.xml"))
+	})
+
+	t.Run("merge spreadsheets and presentations", func(t *testing.T) {
+		content := buildPackage(t, map[string]string{
+			"xl/sharedStrings.xml":  `<sst><si><t>{{ org.address }}</t></si><si><t>{{deal.title}}</t></si></sst>`,
+			"ppt/slides/slide1.xml": `<p:sld><a:p><a:r><a:t>{{person.name}}</a:t></a:r></a:p></p:sld>`,
+		})
+
+		merged, err := service.Merge(context.Background(), content, fields)
+		assert.NoError(t, err)
+		assert.Equal(t, `<sst><si><t></t></si><si><t>Deal &amp; Co</t></si></sst>`,
+			readPackagePart(t, merged, "xl/sharedStrings.xml"))
+		assert.Equal(t, `<p:sld><a:p><a:r><a:t>John</a:t></a:r></a:p></p:sld>`,
+			readPackagePart(t, merged, "ppt/slides/slide1.xml"))
+	})
+
+	t.Run("keep unknown placeholders and other parts", func(t *testing.T) {
+		content := buildPackage(t, map[string]string{
+			"word/document.xml": `<w:p><w:r><w:t>{{deal.unknown}}</w:t></w:r></w:p>`,
+			"word/styles.xml":   `<w:t>{{deal.title}}</w:t>`,
+		})
+
+		merged, err := service.Merge(context.Background(), content, fields)
+		assert.NoError(t, err)
+		assert.Equal(t, `<w:p><w:r><w:t>{{deal.unknown}}</w:t></w:r></w:p>`,
+			readPackagePart(t, merged, "word/document.xml"))
+		assert.Equal(t, `<w:t>{{deal.title}}</w:t>`, readPackagePart(t, merged, "word/styles.xml"))
+	})
+
+	t.Run("merge invalid content", func(t *testing.T) {
+		_, err := service.Merge(context.Background(), []byte("mock"), fields)
+		assert.ErrorIs(t, err, ErrInvalidTemplateContent)
+	})
+}
@@ -0,0 +1,133 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/gateway/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/google/uuid"
+)
+
+// Office Open XML documents are zip archives.
+var zipSignature = []byte("PK\x03\x04")
+
+type templateService struct {
+	adapter port.TemplateServiceAdapter
+	config  *shared.TemplatesConfig
+	logger  plog.Logger
+}
+
+func NewTemplateService(
+	adapter port.TemplateServiceAdapter,
+	config *shared.TemplatesConfig,
+	logger plog.Logger,
+) port.TemplateService {
+	return templateService{
+		adapter: adapter,
+		config:  config,
+		logger:  logger,
+	}
+}
+
+func (s templateService) CreateTemplate(ctx context.Context, template domain.Template, content io.Reader) (domain.Template, error) {
+	template.ID = uuid.NewString()
+	template.CreatedAt = time.Now()
+	if template.Size > s.config.Templates.MaxSize {
+		return template, ErrTemplateTooLarge
+	}
+
+	s.logger.Debugf("validating template %s to perform a persist action", template.Name)
+	if err := template.Validate(); err != nil {
+		return template, err
+	}
+
+	reader := bufio.NewReader(content)
+	signature, err := reader.Peek(len(zipSignature))
+	if err != nil || !bytes.Equal(signature, zipSignature) {
+		return template, ErrInvalidTemplateContent
+	}
+
+	s.logger.Debugf("template %s is valid. Persisting %d bytes", template.ID, template.Size)
+	if err := s.adapter.InsertTemplate(ctx, template, io.LimitReader(reader, s.config.Templates.MaxSize)); err != nil {
+		return template, err
+	}
+
+	return template, nil
+}
+
+func (s templateService) GetTemplates(ctx context.Context, cid string) ([]domain.Template, error) {
+	id := strings.TrimSpace(cid)
+	s.logger.Debugf("trying to select templates of company %s", id)
+
+	if id == "" {
+		return nil, &InvalidServiceParameterError{
+			Name:   "CID",
+			Reason: "Should not be blank",
+		}
+	}
+
+	return s.adapter.SelectTemplates(ctx, id)
+}
+
+func (s templateService) OpenTemplate(ctx context.Context, cid, id string) (domain.Template, io.ReadCloser, error) {
+	cid, id = strings.TrimSpace(cid), strings.TrimSpace(id)
+	s.logger.Debugf("trying to open template %s of company %s", id, cid)
+
+	if cid == "" || id == "" {
+		return domain.Template{}, nil, &InvalidServiceParameterError{
+			Name:   "CID/ID",
+			Reason: "Should not be blank",
+		}
+	}
+
+	template, err := s.adapter.SelectTemplate(ctx, cid, id)
+	if err != nil {
+		return template, nil, err
+	}
+
+	content, err := s.adapter.OpenTemplate(ctx, template)
+	if err != nil {
+		return template, nil, err
+	}
+
+	return template, content, nil
+}
+
+func (s templateService) RemoveTemplate(ctx context.Context, cid, id string) error {
+	cid, id = strings.TrimSpace(cid), strings.TrimSpace(id)
+	s.logger.Debugf("validating template %s of company %s to perform a delete action", id, cid)
+
+	if cid == "" || id == "" {
+		return &InvalidServiceParameterError{
+			Name:   "CID/ID",
+			Reason: "Should not be blank",
+		}
+	}
+
+	return s.adapter.DeleteTemplate(ctx, cid, id)
+}
@@ -94,13 +94,26 @@ func (s *PipedriveHTTPService) InitializeRoutes() {
 			})
 			cr.Get("/me", s.apiController.BuildGetMe())
 			cr.Get("/config", s.apiController.BuildGetConfig())
+			cr.Get("/history", s.apiController.BuildGetHistory())
+			cr.Get("/history/data", s.apiController.BuildGetHistoryData())
+			cr.Post("/convert", s.apiController.BuildPostConvert())
+			cr.Post("/export", s.apiController.BuildPostExport())
 			cr.Post("/settings", s.apiController.BuildPostSettings())
 			cr.Get("/settings", s.apiController.BuildGetSettings())
 			cr.Get("/settings/check", s.apiController.BuildCheckSettings())
+			cr.Get("/settings/status", s.apiController.BuildGetSettingsStatus())
+			cr.Get("/audit", s.apiController.BuildGetAudit())
+			cr.Get("/templates", s.apiController.BuildGetTemplates())
+			cr.Post("/templates", s.apiController.BuildPostTemplate())
+			cr.Delete("/templates", s.apiController.BuildDeleteTemplate())
+			cr.Get("/sessions", s.apiController.BuildGetSession())
+			cr.Post("/sessions/forcesave", s.apiController.BuildPostSessionForceSave())
+			cr.Post("/sessions/disconnect", s.apiController.BuildPostSessionDisconnect())
 		})
 
 		r.Route("/files", func(fr chi.Router) {
 			fr.Get("/download", s.fileController.BuildGetDownloadUrl())
+			fr.Get("/proxy", s.fileController.BuildGetProxyDownload())
 			fr.Get("/create", s.contextMiddleware.Protect(s.fileController.BuildGetFile()))
 		})
 	})
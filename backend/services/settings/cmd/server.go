@@ -26,6 +26,9 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/settings/web/core/service"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/settings/web/handler"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
 	"github.com/urfave/cli/v2"
 )
 
@@ -53,7 +56,18 @@ func Server() *cli.Command {
 				handler.NewSettingsSelectHandler,
 				handler.NewSettingsInsertHandler,
 				handler.NewSettingsDeleteHandler,
+				handler.NewSettingsStatusHandler,
+				handler.NewDocServerHealthChecker,
 				shared.BuildNewIntegrationCredentialsConfig(CONFIG_PATH),
+				shared.BuildNewDistributedCacheConfig(CONFIG_PATH),
+				cache.NewCache,
+				shared.NewEventPublisher,
+				shared.BuildNewOnlyofficeConfig(CONFIG_PATH),
+				shared.BuildNewHealthConfig(CONFIG_PATH),
+				client.NewCommandClient,
+			), pkg.WithInvokables(
+				handler.RunDocServerHealthChecker,
+				cache.BuildRunKeyedInvalidator(handler.SettingsCacheKeys, request.EventSettingsUpdated, request.EventSettingsDeleted),
 			)).Bootstrap()
 
 			if err := app.Err(); err != nil {
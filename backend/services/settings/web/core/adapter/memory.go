@@ -21,6 +21,7 @@ package adapter
 import (
 	"context"
 	"encoding/json"
+	"sort"
 
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/settings/web/core/domain"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/settings/web/core/port"
@@ -84,3 +85,13 @@ func (m *memoryDocserverAdapter) DeleteSettings(ctx context.Context, cid string)
 
 	return nil
 }
+
+func (m *memoryDocserverAdapter) SelectCompanies(ctx context.Context) ([]string, error) {
+	companies := make([]string, 0, len(m.kvs))
+	for cid := range m.kvs {
+		companies = append(companies, cid)
+	}
+
+	sort.Strings(companies)
+	return companies, nil
+}
@@ -53,6 +53,31 @@ func TestMemoryAdapter(t *testing.T) {
 		assert.NotNil(t, s)
 	})
 
+	t.Run("update settings permissions by cid", func(t *testing.T) {
+		_, err := adapter.UpsertSettings(context.Background(), domain.DocSettings{
+			CompanyID:  "mock",
+			DocAddress: "mock",
+			DocSecret:  "mock",
+			Permissions: &domain.DocPermissions{
+				Print:           true,
+				DisableDownload: true,
+			},
+		})
+		assert.NoError(t, err)
+
+		s, err := adapter.SelectSettings(context.Background(), "mock")
+		assert.NoError(t, err)
+		assert.True(t, s.Permissions.Print)
+		assert.True(t, s.Permissions.DisableDownload)
+		assert.False(t, s.Permissions.ForceReview)
+	})
+
+	t.Run("select companies with settings", func(t *testing.T) {
+		companies, err := adapter.SelectCompanies(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"mock"}, companies)
+	})
+
 	t.Run("delete settings by cid", func(t *testing.T) {
 		assert.NoError(t, adapter.DeleteSettings(context.Background(), "mock"))
 	})
@@ -33,14 +33,82 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+type docPermissionsCollection struct {
+	Print                   bool `json:"print" bson:"print"`
+	DisableDownload         bool `json:"disable_download" bson:"disable_download"`
+	ForceReview             bool `json:"force_review" bson:"force_review"`
+	EditCommentAuthorOnly   bool `json:"edit_comment_author_only" bson:"edit_comment_author_only"`
+	DeleteCommentAuthorOnly bool `json:"delete_comment_author_only" bson:"delete_comment_author_only"`
+}
+
+type docRolesCollection struct {
+	Admin          string            `json:"admin" bson:"admin"`
+	Regular        string            `json:"regular" bson:"regular"`
+	PermissionSets map[string]string `json:"permission_sets" bson:"permission_sets"`
+}
+
 type docSettingsCollection struct {
 	mgm.DefaultModel `bson:",inline"`
-	CompanyID        string    `json:"company_id" bson:"company_id"`
-	DocAddress       string    `json:"doc_address" bson:"doc_address"`
-	DocSecret        string    `json:"doc_secret" bson:"doc_secret"`
-	DocHeader        string    `json:"doc_header" bson:"doc_header"`
-	DemoEnabled      bool      `json:"demo_enabled" bson:"demo_enabled"`
-	DemoStarted      time.Time `json:"demo_started" bson:"demo_started"`
+	CompanyID        string                    `json:"company_id" bson:"company_id"`
+	DocAddress       string                    `json:"doc_address" bson:"doc_address"`
+	DocSecret        string                    `json:"doc_secret" bson:"doc_secret"`
+	DocHeader        string                    `json:"doc_header" bson:"doc_header"`
+	DemoEnabled      bool                      `json:"demo_enabled" bson:"demo_enabled"`
+	DemoStarted      time.Time                 `json:"demo_started" bson:"demo_started"`
+	Permissions      *docPermissionsCollection `json:"permissions,omitempty" bson:"permissions,omitempty"`
+	Roles            *docRolesCollection       `json:"roles,omitempty" bson:"roles,omitempty"`
+}
+
+func toPermissionsCollection(permissions *domain.DocPermissions) *docPermissionsCollection {
+	if permissions == nil {
+		return nil
+	}
+
+	return &docPermissionsCollection{
+		Print:                   permissions.Print,
+		DisableDownload:         permissions.DisableDownload,
+		ForceReview:             permissions.ForceReview,
+		EditCommentAuthorOnly:   permissions.EditCommentAuthorOnly,
+		DeleteCommentAuthorOnly: permissions.DeleteCommentAuthorOnly,
+	}
+}
+
+func toRolesCollection(roles *domain.DocRoles) *docRolesCollection {
+	if roles == nil {
+		return nil
+	}
+
+	return &docRolesCollection{
+		Admin:          roles.Admin,
+		Regular:        roles.Regular,
+		PermissionSets: roles.PermissionSets,
+	}
+}
+
+func (r *docRolesCollection) toDomain() *domain.DocRoles {
+	if r == nil {
+		return nil
+	}
+
+	return &domain.DocRoles{
+		Admin:          r.Admin,
+		Regular:        r.Regular,
+		PermissionSets: r.PermissionSets,
+	}
+}
+
+func (p *docPermissionsCollection) toDomain() *domain.DocPermissions {
+	if p == nil {
+		return nil
+	}
+
+	return &domain.DocPermissions{
+		Print:                   p.Print,
+		DisableDownload:         p.DisableDownload,
+		ForceReview:             p.ForceReview,
+		EditCommentAuthorOnly:   p.EditCommentAuthorOnly,
+		DeleteCommentAuthorOnly: p.DeleteCommentAuthorOnly,
+	}
 }
 
 type mongoUserAdapter struct {
@@ -70,6 +138,8 @@ func (m *mongoUserAdapter) save(ctx context.Context, settings domain.DocSettings
 				DocHeader:   settings.DocHeader,
 				DemoEnabled: settings.DemoEnabled,
 				DemoStarted: settings.DemoStarted,
+				Permissions: toPermissionsCollection(settings.Permissions),
+				Roles:       toRolesCollection(settings.Roles),
 			}); cerr != nil {
 				return cerr
 			}
@@ -86,6 +156,14 @@ func (m *mongoUserAdapter) save(ctx context.Context, settings domain.DocSettings
 			u.DemoStarted = settings.DemoStarted
 		}
 
+		if settings.Permissions != nil {
+			u.Permissions = toPermissionsCollection(settings.Permissions)
+		}
+
+		if settings.Roles != nil {
+			u.Roles = toRolesCollection(settings.Roles)
+		}
+
 		u.UpdatedAt = time.Now()
 
 		if err := collection.UpdateWithCtx(ctx, u); err != nil {
@@ -125,6 +203,8 @@ func (m *mongoUserAdapter) SelectSettings(ctx context.Context, cid string) (doma
 		DocHeader:   settings.DocHeader,
 		DemoEnabled: settings.DemoEnabled,
 		DemoStarted: settings.DemoStarted,
+		Permissions: settings.Permissions.toDomain(),
+		Roles:       settings.Roles.toDomain(),
 	}, nil
 }
 
@@ -146,3 +226,19 @@ func (m *mongoUserAdapter) DeleteSettings(ctx context.Context, cid string) error
 	_, err := mgm.Coll(&docSettingsCollection{}).DeleteMany(ctx, bson.M{"company_id": bson.M{operator.Eq: cid}})
 	return err
 }
+
+func (m *mongoUserAdapter) SelectCompanies(ctx context.Context) ([]string, error) {
+	values, err := mgm.Coll(&docSettingsCollection{}).Distinct(ctx, "company_id", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	companies := make([]string, 0, len(values))
+	for _, value := range values {
+		if cid, ok := value.(string); ok && cid != "" {
+			companies = append(companies, cid)
+		}
+	}
+
+	return companies, nil
+}
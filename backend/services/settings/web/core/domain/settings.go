@@ -26,13 +26,78 @@ import (
 	"time"
 )
 
+type DocPermissions struct {
+	Print                   bool `json:"print" mapstructure:"print"`
+	DisableDownload         bool `json:"disable_download" mapstructure:"disable_download"`
+	ForceReview             bool `json:"force_review" mapstructure:"force_review"`
+	EditCommentAuthorOnly   bool `json:"edit_comment_author_only" mapstructure:"edit_comment_author_only"`
+	DeleteCommentAuthorOnly bool `json:"delete_comment_author_only" mapstructure:"delete_comment_author_only"`
+}
+
+const (
+	DocModeEdit    = "edit"
+	DocModeReview  = "review"
+	DocModeComment = "comment"
+	DocModeView    = "view"
+)
+
+type DocRoles struct {
+	Admin          string            `json:"admin" mapstructure:"admin"`
+	Regular        string            `json:"regular" mapstructure:"regular"`
+	PermissionSets map[string]string `json:"permission_sets" mapstructure:"permission_sets"`
+}
+
+func isValidDocMode(mode string) bool {
+	switch mode {
+	case "", DocModeEdit, DocModeReview, DocModeComment, DocModeView:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *DocRoles) Validate() error {
+	r.Admin = strings.TrimSpace(r.Admin)
+	r.Regular = strings.TrimSpace(r.Regular)
+
+	if !isValidDocMode(r.Admin) {
+		return &InvalidModelFieldError{
+			Model:  "Docserver",
+			Field:  "Admin Role",
+			Reason: "Unknown editor mode",
+		}
+	}
+
+	if !isValidDocMode(r.Regular) {
+		return &InvalidModelFieldError{
+			Model:  "Docserver",
+			Field:  "Regular Role",
+			Reason: "Unknown editor mode",
+		}
+	}
+
+	for id, mode := range r.PermissionSets {
+		if strings.TrimSpace(id) == "" || !isValidDocMode(mode) {
+			return &InvalidModelFieldError{
+				Model:  "Docserver",
+				Field:  "Permission Sets",
+				Reason: fmt.Sprintf("Invalid permission set %s mode %s", id, mode),
+			}
+		}
+	}
+
+	return nil
+}
+
 type DocSettings struct {
-	CompanyID   string    `json:"company_id" mapstructure:"company_id"`
-	DocAddress  string    `json:"doc_address" mapstructure:"doc_address"`
-	DocSecret   string    `json:"doc_secret" mapstructure:"doc_secret"`
-	DocHeader   string    `json:"doc_header" mapstructure:"doc_header"`
-	DemoEnabled bool      `json:"demo_enabled" mapstructure:"demo_enabled"`
-	DemoStarted time.Time `json:"demo_started" mapstructure:"demo_started"`
+	CompanyID   string          `json:"company_id" mapstructure:"company_id"`
+	DocAddress  string          `json:"doc_address" mapstructure:"doc_address"`
+	DocSecret   string          `json:"doc_secret" mapstructure:"doc_secret"`
+	DocHeader   string          `json:"doc_header" mapstructure:"doc_header"`
+	DemoEnabled bool            `json:"demo_enabled" mapstructure:"demo_enabled"`
+	DemoStarted time.Time       `json:"demo_started" mapstructure:"demo_started"`
+	Permissions *DocPermissions `json:"permissions,omitempty" mapstructure:"permissions"`
+	Roles       *DocRoles       `json:"roles,omitempty" mapstructure:"roles"`
 }
 
 func (u DocSettings) ToJSON() []byte {
@@ -54,6 +119,12 @@ func (u *DocSettings) Validate() error {
 		}
 	}
 
+	if u.Roles != nil {
+		if err := u.Roles.Validate(); err != nil {
+			return err
+		}
+	}
+
 	if u.DemoEnabled {
 		if u.DemoStarted.IsZero() {
 			u.DemoStarted = time.Now()
@@ -29,4 +29,5 @@ type DocSettingsService interface {
 	GetSettings(ctx context.Context, cid string) (domain.DocSettings, error)
 	UpdateSettings(ctx context.Context, settings domain.DocSettings) (domain.DocSettings, error)
 	RemoveSettings(ctx context.Context, cid string) error
+	GetCompanies(ctx context.Context) ([]string, error)
 }
@@ -29,4 +29,5 @@ type DocSettingsServiceAdapter interface {
 	SelectSettings(ctx context.Context, cid string) (domain.DocSettings, error)
 	UpsertSettings(ctx context.Context, settings domain.DocSettings) (domain.DocSettings, error)
 	DeleteSettings(ctx context.Context, cid string) error
+	SelectCompanies(ctx context.Context) ([]string, error)
 }
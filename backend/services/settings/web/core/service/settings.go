@@ -27,8 +27,10 @@ import (
 	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/settings/web/core/domain"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/settings/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
 	"github.com/mitchellh/mapstructure"
-	"go-micro.dev/v4/cache"
 	"golang.org/x/oauth2"
 )
 
@@ -36,6 +38,7 @@ type settingsService struct {
 	adapter     port.DocSettingsServiceAdapter
 	encryptor   crypto.Encryptor
 	cache       cache.Cache
+	events      shared.EventPublisher
 	credentials *oauth2.Config
 	logger      plog.Logger
 }
@@ -44,6 +47,7 @@ func NewSettingsService(
 	adapter port.DocSettingsServiceAdapter,
 	encryptor crypto.Encryptor,
 	cache cache.Cache,
+	events shared.EventPublisher,
 	credentials *oauth2.Config,
 	logger plog.Logger,
 ) port.DocSettingsService {
@@ -51,6 +55,7 @@ func NewSettingsService(
 		adapter:     adapter,
 		encryptor:   encryptor,
 		cache:       cache,
+		events:      events,
 		credentials: credentials,
 		logger:      logger,
 	}
@@ -75,6 +80,8 @@ func (s settingsService) CreateSettings(ctx context.Context, settings domain.Doc
 		DocHeader:   settings.DocHeader,
 		DemoEnabled: settings.DemoEnabled,
 		DemoStarted: settings.DemoStarted,
+		Permissions: settings.Permissions,
+		Roles:       settings.Roles,
 	}); err != nil {
 		return err
 	}
@@ -116,6 +123,16 @@ func (s settingsService) GetSettings(ctx context.Context, cid string) (domain.Do
 		return settings, err
 	}
 
+	permissions := settings.Permissions
+	if permissions == nil {
+		permissions = &domain.DocPermissions{}
+	}
+
+	roles := settings.Roles
+	if roles == nil {
+		roles = &domain.DocRoles{}
+	}
+
 	return domain.DocSettings{
 		CompanyID:   cid,
 		DocAddress:  settings.DocAddress,
@@ -123,6 +140,8 @@ func (s settingsService) GetSettings(ctx context.Context, cid string) (domain.Do
 		DocHeader:   settings.DocHeader,
 		DemoEnabled: settings.DemoEnabled,
 		DemoStarted: settings.DemoStarted,
+		Permissions: permissions,
+		Roles:       roles,
 	}, nil
 }
 
@@ -138,6 +157,14 @@ func (s settingsService) UpdateSettings(ctx context.Context, settings domain.Doc
 			settings.DemoStarted = time.Now()
 		}
 	} else {
+		if settings.Permissions == nil {
+			settings.Permissions = persistedSettings.Permissions
+		}
+
+		if settings.Roles == nil {
+			settings.Roles = persistedSettings.Roles
+		}
+
 		if settings.DemoEnabled {
 			if persistedSettings.DemoEnabled && !persistedSettings.DemoStarted.IsZero() {
 				settings.DemoStarted = persistedSettings.DemoStarted
@@ -160,11 +187,13 @@ func (s settingsService) UpdateSettings(ctx context.Context, settings domain.Doc
 		DocHeader:   settings.DocHeader,
 		DemoEnabled: settings.DemoEnabled,
 		DemoStarted: settings.DemoStarted,
+		Permissions: settings.Permissions,
+		Roles:       settings.Roles,
 	}); err != nil {
 		return settings, err
 	}
 
-	s.cache.Delete(ctx, settings.CompanyID)
+	s.invalidate(ctx, request.EventSettingsUpdated, settings.CompanyID)
 
 	s.logger.Debugf("successfully persisted %s settings", settings.CompanyID)
 	return settings, nil
@@ -181,8 +210,25 @@ func (s settingsService) RemoveSettings(ctx context.Context, cid string) error {
 		}
 	}
 
-	s.cache.Delete(ctx, cid)
-
 	s.logger.Debugf("uid %s is valid to perform a delete action", id)
-	return s.adapter.DeleteSettings(ctx, id)
+	if err := s.adapter.DeleteSettings(ctx, id); err != nil {
+		return err
+	}
+
+	s.events.Publish(request.EventSettingsDeleted, id)
+	return s.cache.Invalidate(ctx, id)
+}
+
+// invalidate drops cached settings here and tells other instances to drop theirs.
+func (s settingsService) invalidate(ctx context.Context, event, cid string) {
+	if err := s.cache.Invalidate(ctx, cid); err != nil {
+		s.logger.Warnf("could not invalidate cached company %s settings: %s", cid, err.Error())
+	}
+
+	s.events.Publish(event, cid)
+}
+
+func (s settingsService) GetCompanies(ctx context.Context) ([]string, error) {
+	s.logger.Debug("trying to select all companies with settings")
+	return s.adapter.SelectCompanies(ctx)
 }
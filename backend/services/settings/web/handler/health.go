@@ -0,0 +1,244 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/settings/web/core/domain"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/settings/web/core/port"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+	"github.com/mitchellh/mapstructure"
+	"go.uber.org/fx"
+)
+
+const healthCheckerLease = "docserver-health-lease"
+
+type DocServerHealthChecker struct {
+	service       port.DocSettingsService
+	commandClient client.CommandClient
+	cache         cache.Cache
+	config        *shared.HealthConfig
+	onlyoffice    *shared.OnlyofficeConfig
+	logger        log.Logger
+}
+
+func NewDocServerHealthChecker(
+	service port.DocSettingsService,
+	commandClient client.CommandClient,
+	cache cache.Cache,
+	config *shared.HealthConfig,
+	onlyoffice *shared.OnlyofficeConfig,
+	logger log.Logger,
+) DocServerHealthChecker {
+	return DocServerHealthChecker{
+		service:       service,
+		commandClient: commandClient,
+		cache:         cache,
+		config:        config,
+		onlyoffice:    onlyoffice,
+		logger:        logger,
+	}
+}
+
+func healthKey(cid string) string {
+	return fmt.Sprintf("docserver-health-%s", cid)
+}
+
+// SettingsCacheKeys lists the cached entries of a company that turn stale once
+// its settings change, including the status of the previous document server.
+func SettingsCacheKeys(cid string) []string {
+	return []string{cid, healthKey(cid)}
+}
+
+// RunDocServerHealthChecker starts checking document servers in the background for the app's lifetime.
+func RunDocServerHealthChecker(lifecycle fx.Lifecycle, checker DocServerHealthChecker) {
+	if !checker.config.Health.Enabled {
+		checker.logger.Debug("document server health checker is disabled")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				checker.Run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+func (c DocServerHealthChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(c.config.Health.Interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if c.lease(ctx) {
+			c.CheckAll(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// lease lets a single settings replica check document servers per interval, so
+// that the load on document servers does not grow with the number of replicas.
+// A replica that can not reach the cache sits the round out.
+func (c DocServerHealthChecker) lease(ctx context.Context) bool {
+	ok, err := c.cache.Acquire(ctx, healthCheckerLease, time.Duration(c.config.Health.Interval)*time.Second)
+	if err != nil {
+		c.logger.Errorf("could not acquire the document server health checker lease. Reason: %s", err.Error())
+		return false
+	}
+
+	if !ok {
+		c.logger.Debug("document server health checker lease is held by another replica")
+	}
+
+	return ok
+}
+
+// CheckAll checks the document server of every company with settings.
+func (c DocServerHealthChecker) CheckAll(ctx context.Context) {
+	companies, err := c.service.GetCompanies(ctx)
+	if err != nil {
+		c.logger.Errorf("could not select companies to check document servers. Reason: %s", err.Error())
+		return
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, c.config.Health.Concurrency)
+	for _, cid := range companies {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(cid string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			c.Check(ctx, cid)
+		}(cid)
+	}
+
+	wg.Wait()
+}
+
+// Check checks a company's document server and caches the result until the next round.
+func (c DocServerHealthChecker) Check(ctx context.Context, cid string) response.DocServerStatusResponse {
+	status := response.DocServerStatusResponse{CheckedAt: time.Now().UTC()}
+	settings, err := c.service.GetSettings(ctx, cid)
+	if err != nil {
+		c.logger.Debugf("could not get company %s settings to check its document server. Reason: %s", cid, err.Error())
+		return status
+	}
+
+	address, secret, header := c.resolveDocServer(settings, &status)
+	if address == "" {
+		c.store(ctx, cid, status)
+		return status
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Health.Timeout)*time.Second)
+	defer cancel()
+
+	started := time.Now()
+	version, err := c.commandClient.Version(tctx, address, secret, header)
+	status.Latency = time.Since(started).Milliseconds()
+	if err != nil {
+		if ctx.Err() != nil {
+			return status
+		}
+
+		c.logger.Warnf("document server of company %s is not reachable. Reason: %s", cid, err.Error())
+		status.Error = err.Error()
+	} else {
+		status.Reachable = true
+		status.Version = version
+	}
+
+	c.store(ctx, cid, status)
+	return status
+}
+
+// resolveDocServer picks the document server the company currently opens documents with.
+func (c DocServerHealthChecker) resolveDocServer(settings domain.DocSettings, status *response.DocServerStatusResponse) (string, string, string) {
+	demo := settings.DemoEnabled && (settings.DemoStarted.IsZero() || settings.DemoStarted.After(time.Now().AddDate(0, 0, -30)))
+	if settings.DocAddress != "" && settings.DocSecret != "" && !demo {
+		status.Configured = true
+		return settings.DocAddress, settings.DocSecret, settings.DocHeader
+	}
+
+	if demo && c.onlyoffice.Onlyoffice.Demo.DocumentServerURL != "" {
+		status.Configured = true
+		status.Demo = true
+		return c.onlyoffice.Onlyoffice.Demo.DocumentServerURL, c.onlyoffice.Onlyoffice.Demo.DocumentServerSecret,
+			c.onlyoffice.Onlyoffice.Demo.DocumentServerHeader
+	}
+
+	return "", "", ""
+}
+
+func (c DocServerHealthChecker) store(ctx context.Context, cid string, status response.DocServerStatusResponse) {
+	// Results outlive a few missed rounds so that a slow round does not blank the status.
+	ttl := 3 * time.Duration(c.config.Health.Interval) * time.Second
+	if err := c.cache.Put(ctx, healthKey(cid), status, ttl); err != nil {
+		c.logger.Warnf("could not cache company %s document server status. Reason: %s", cid, err.Error())
+	}
+}
+
+// Status returns the cached status of a company's document server or checks it right away.
+func (c DocServerHealthChecker) Status(ctx context.Context, cid string) response.DocServerStatusResponse {
+	var status response.DocServerStatusResponse
+	if res, _, err := c.cache.Get(ctx, healthKey(cid)); err == nil && res != nil {
+		if err := mapstructure.Decode(res, &status); err == nil && !status.CheckedAt.IsZero() {
+			return status
+		}
+	}
+
+	return c.Check(ctx, cid)
+}
@@ -45,12 +45,34 @@ func NewSettingsInsertHandler(
 
 func (i SettingsInsertHandler) InsertSettings(ctx context.Context, req request.DocSettings, res *interface{}) error {
 	_, err, _ := group.Do(fmt.Sprintf("insert-%d", req.CompanyID), func() (interface{}, error) {
+		var permissions *domain.DocPermissions
+		if req.Permissions != nil {
+			permissions = &domain.DocPermissions{
+				Print:                   req.Permissions.Print,
+				DisableDownload:         req.Permissions.DisableDownload,
+				ForceReview:             req.Permissions.ForceReview,
+				EditCommentAuthorOnly:   req.Permissions.EditCommentAuthorOnly,
+				DeleteCommentAuthorOnly: req.Permissions.DeleteCommentAuthorOnly,
+			}
+		}
+
+		var roles *domain.DocRoles
+		if req.Roles != nil {
+			roles = &domain.DocRoles{
+				Admin:          req.Roles.Admin,
+				Regular:        req.Roles.Regular,
+				PermissionSets: req.Roles.PermissionSets,
+			}
+		}
+
 		settings, err := i.service.UpdateSettings(ctx, domain.DocSettings{
 			CompanyID:   fmt.Sprint(req.CompanyID),
 			DocAddress:  req.DocAddress,
 			DocHeader:   req.DocHeader,
 			DocSecret:   req.DocSecret,
 			DemoEnabled: req.DemoEnabled,
+			Permissions: permissions,
+			Roles:       roles,
 		})
 
 		if err != nil {
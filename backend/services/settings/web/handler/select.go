@@ -65,6 +65,25 @@ func (u SettingsSelectHandler) GetSettings(ctx context.Context, cid *string, res
 			DemoEnabled: set.DemoEnabled,
 			DemoStarted: set.DemoStarted,
 		}
+
+		if set.Permissions != nil {
+			res.Permissions = response.DocPermissionsResponse{
+				Print:                   set.Permissions.Print,
+				DisableDownload:         set.Permissions.DisableDownload,
+				ForceReview:             set.Permissions.ForceReview,
+				EditCommentAuthorOnly:   set.Permissions.EditCommentAuthorOnly,
+				DeleteCommentAuthorOnly: set.Permissions.DeleteCommentAuthorOnly,
+			}
+		}
+
+		if set.Roles != nil {
+			res.Roles = response.DocRolesResponse{
+				Admin:          set.Roles.Admin,
+				Regular:        set.Roles.Regular,
+				PermissionSets: set.Roles.PermissionSets,
+			}
+		}
+
 		return nil
 	}
 
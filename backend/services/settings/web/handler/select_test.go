@@ -23,14 +23,17 @@ import (
 	"testing"
 	"time"
 
-	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/cache"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/settings/web/core/adapter"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/settings/web/core/domain"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/settings/web/core/service"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/cache"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
 	"github.com/stretchr/testify/assert"
+	"go-micro.dev/v4/broker"
 	"golang.org/x/oauth2"
 )
 
@@ -44,10 +47,16 @@ func (e mockEncryptor) Decrypt(ciphertext string, key []byte) (string, error) {
 	return string(ciphertext), nil
 }
 
+func newEventPublisher() shared.EventPublisher {
+	return shared.NewEventPublisher(messaging.BrokerWithOptions{
+		Broker: broker.NewMemoryBroker(),
+	}, &config.ServerConfig{}, log.NewEmptyLogger())
+}
+
 func TestSelectCaching(t *testing.T) {
 	adapter := adapter.NewMemoryDocserverAdapter()
 	service := service.NewSettingsService(
-		adapter, mockEncryptor{}, cache.NewCache(&config.CacheConfig{}),
+		adapter, mockEncryptor{}, cache.NewMemoryCache(), newEventPublisher(),
 		&oauth2.Config{
 			ClientID:     "mock",
 			ClientSecret: "mock",
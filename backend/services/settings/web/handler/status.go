@@ -0,0 +1,56 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+)
+
+type SettingsStatusHandler struct {
+	checker DocServerHealthChecker
+	logger  log.Logger
+}
+
+func NewSettingsStatusHandler(
+	checker DocServerHealthChecker,
+	logger log.Logger,
+) SettingsStatusHandler {
+	return SettingsStatusHandler{
+		checker: checker,
+		logger:  logger,
+	}
+}
+
+func (u SettingsStatusHandler) GetStatus(ctx context.Context, cid *string, res *response.DocServerStatusResponse) error {
+	id := strings.TrimSpace(*cid)
+	status, _, _ := group.Do(fmt.Sprintf("status-%s", id), func() (interface{}, error) {
+		return u.checker.Status(ctx, id), nil
+	})
+
+	if st, ok := status.(response.DocServerStatusResponse); ok {
+		*res = st
+	}
+
+	return nil
+}
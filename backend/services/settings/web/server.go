@@ -27,17 +27,20 @@ type DocserverRPCServer struct {
 	selectHandler handler.SettingsSelectHandler
 	insertHandler handler.SettingsInsertHandler
 	deleteHandler handler.SettingsDeleteHandler
+	statusHandler handler.SettingsStatusHandler
 }
 
 func NewDocserverRPCServer(
 	selectHandler handler.SettingsSelectHandler,
 	insertHandler handler.SettingsInsertHandler,
 	deleteHandler handler.SettingsDeleteHandler,
+	statusHandler handler.SettingsStatusHandler,
 ) rpc.RPCEngine {
 	return DocserverRPCServer{
 		selectHandler: selectHandler,
 		insertHandler: insertHandler,
 		deleteHandler: deleteHandler,
+		statusHandler: statusHandler,
 	}
 }
 
@@ -46,5 +49,5 @@ func (a DocserverRPCServer) BuildMessageHandlers() []rpc.RPCMessageHandler {
 }
 
 func (a DocserverRPCServer) BuildHandlers() []interface{} {
-	return []interface{}{a.selectHandler, a.insertHandler, a.deleteHandler}
+	return []interface{}{a.selectHandler, a.insertHandler, a.deleteHandler, a.statusHandler}
 }
@@ -0,0 +1,119 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"go-micro.dev/v4/broker"
+	"go-micro.dev/v4/client"
+)
+
+const auditAttempts = 3
+
+// AuditTopic is the broker topic audit events are published to.
+func AuditTopic(namespace string) string {
+	return EventTopic(namespace, "audit.emitted")
+}
+
+// AuditEmitter sends audit events to the audit service without blocking the
+// request that produced them. With messaging enabled events are published to
+// the broker, which holds them while the audit service is down or slow.
+// Otherwise they are sent over rpc with a few retries. Events that still can
+// not be delivered are counted and logged in full at error level.
+type AuditEmitter struct {
+	broker    messaging.BrokerWithOptions
+	client    client.Client
+	config    *config.ServerConfig
+	messaging *config.BrokerConfig
+	logger    log.Logger
+	dropped   *uint64
+}
+
+func NewAuditEmitter(
+	broker messaging.BrokerWithOptions,
+	client client.Client,
+	config *config.ServerConfig,
+	messaging *config.BrokerConfig,
+	logger log.Logger,
+) AuditEmitter {
+	return AuditEmitter{
+		broker:    broker,
+		client:    client,
+		config:    config,
+		messaging: messaging,
+		logger:    logger,
+		dropped:   new(uint64),
+	}
+}
+
+func (e AuditEmitter) Emit(event request.AuditEvent) {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	if e.messaging.Messaging.Enable {
+		err := e.broker.Broker.Publish(AuditTopic(e.config.Namespace), &broker.Message{
+			Header: map[string]string{"Content-Type": "application/json"},
+			Body:   event.ToJSON(),
+		})
+		if err == nil {
+			return
+		}
+
+		e.logger.Warnf("could not publish %s audit event, falling back to rpc: %s", event.Type, err.Error())
+	}
+
+	go e.send(event)
+}
+
+func (e AuditEmitter) send(event request.AuditEvent) {
+	var err error
+	for attempt := 0; attempt < auditAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(1<<(attempt-1)) * time.Second)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		var res interface{}
+		err = e.client.Call(ctx, e.client.NewRequest(
+			fmt.Sprintf("%s:audit", e.config.Namespace),
+			"AuditInsertHandler.InsertEvent", event,
+		), &res)
+		cancel()
+
+		if err == nil {
+			return
+		}
+
+		e.logger.Warnf("could not emit %s audit event (attempt %d): %s", event.Type, attempt+1, err.Error())
+	}
+
+	e.logger.Errorf(
+		"dropped %s audit event (%d dropped so far): %s. Event: %s",
+		event.Type, atomic.AddUint64(e.dropped, 1), err.Error(), string(event.ToJSON()),
+	)
+}
@@ -0,0 +1,85 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/vmihailenco/msgpack/v5"
+	"go-micro.dev/v4/cache"
+)
+
+var ErrCacheMiss = errors.New("cache entry not found")
+
+// Cache is a go-micro compatible cache whose invalidations reach every replica
+// sharing the same backend.
+type Cache interface {
+	cache.Cache
+	// Invalidate drops entries that have been changed elsewhere.
+	Invalidate(ctx context.Context, keys ...string) error
+	// Acquire stores the key only if it is not present yet and reports whether
+	// it did, so that the entry works as a lease held until it expires.
+	Acquire(ctx context.Context, key string, d time.Duration) (bool, error)
+	// Increment atomically adds delta to the counter stored under the key and
+	// returns the result. A new counter expires after d. Counters are only
+	// meant to be read back through Increment.
+	Increment(ctx context.Context, key string, delta int64, d time.Duration) (int64, error)
+}
+
+// NewCache builds the cache backend selected in the distributed cache configuration.
+// Keys are prefixed with the service namespace and name so that services may share a backend.
+func NewCache(cacheConfig *shared.DistributedCacheConfig, serverConfig *config.ServerConfig) Cache {
+	prefix := fmt.Sprintf("%s:%s:", serverConfig.Namespace, serverConfig.Name)
+	switch cacheConfig.Cache.Type {
+	case shared.DistributedCacheRedis:
+		return NewRedisCache(
+			cacheConfig.Cache.Address, cacheConfig.Cache.Username,
+			cacheConfig.Cache.Password, cacheConfig.Cache.Database, prefix,
+		)
+	default:
+		return NewMemoryCache()
+	}
+}
+
+// Values are stored encoded, the way a remote store would hand them back,
+// so that callers decode cached entries the same way regardless of the backend.
+// Entries are keyed by json names, which domain models share with their
+// mapstructure tags.
+func encode(val interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(val); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decode(buf []byte) (interface{}, error) {
+	var res interface{}
+	err := msgpack.Unmarshal(buf, &res)
+	return res, err
+}
@@ -0,0 +1,145 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"go-micro.dev/v4/broker"
+	"go.uber.org/fx"
+)
+
+// Invalidator drops cached entries named by change events.
+type Invalidator struct {
+	broker        messaging.BrokerWithOptions
+	cache         Cache
+	namespace     string
+	events        []string
+	keys          func(id string) []string
+	logger        log.Logger
+	subscriptions []broker.Subscriber
+}
+
+func NewInvalidator(
+	broker messaging.BrokerWithOptions,
+	cache Cache,
+	namespace string,
+	logger log.Logger,
+	events ...string,
+) *Invalidator {
+	return &Invalidator{
+		broker:    broker,
+		cache:     cache,
+		namespace: namespace,
+		events:    events,
+		logger:    logger,
+	}
+}
+
+// WithKeys maps every changed entity to the cache keys derived from it. By
+// default the entity id itself is dropped.
+func (i *Invalidator) WithKeys(keys func(id string) []string) *Invalidator {
+	i.keys = keys
+	return i
+}
+
+func (i *Invalidator) Handle(e broker.Event) error {
+	var event request.ChangeEvent
+	if err := json.Unmarshal(e.Message().Body, &event); err != nil {
+		i.logger.Warnf("could not decode a %s event: %s", e.Topic(), err.Error())
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	keys := event.IDs
+	if i.keys != nil {
+		keys = make([]string, 0, len(event.IDs))
+		for _, id := range event.IDs {
+			keys = append(keys, i.keys(id)...)
+		}
+	}
+
+	i.logger.Debugf("dropping %d cached entries on %s", len(keys), e.Topic())
+	return i.cache.Invalidate(ctx, keys...)
+}
+
+// Subscribe listens to every event without a queue. Queue subscribers, such as
+// the ones registered through BuildMessageHandlers, share events between
+// replicas, while each replica has to drop its own entries.
+func (i *Invalidator) Subscribe() error {
+	for _, event := range i.events {
+		sub, err := i.broker.Broker.Subscribe(shared.EventTopic(i.namespace, event), i.Handle)
+		if err != nil {
+			i.Unsubscribe()
+			return err
+		}
+
+		i.subscriptions = append(i.subscriptions, sub)
+	}
+
+	return nil
+}
+
+func (i *Invalidator) Unsubscribe() {
+	for _, sub := range i.subscriptions {
+		if err := sub.Unsubscribe(); err != nil {
+			i.logger.Warnf("could not unsubscribe from %s: %s", sub.Topic(), err.Error())
+		}
+	}
+
+	i.subscriptions = nil
+}
+
+// BuildRunInvalidator returns an fx invokable that keeps the service cache in
+// sync with the given change events.
+func BuildRunInvalidator(events ...string) func(fx.Lifecycle, messaging.BrokerWithOptions, *config.ServerConfig, Cache, log.Logger) {
+	return BuildRunKeyedInvalidator(nil, events...)
+}
+
+// BuildRunKeyedInvalidator is BuildRunInvalidator for services that cache
+// several entries per changed entity.
+func BuildRunKeyedInvalidator(keys func(id string) []string, events ...string) func(fx.Lifecycle, messaging.BrokerWithOptions, *config.ServerConfig, Cache, log.Logger) {
+	return func(
+		lifecycle fx.Lifecycle,
+		broker messaging.BrokerWithOptions,
+		config *config.ServerConfig,
+		cache Cache,
+		logger log.Logger,
+	) {
+		invalidator := NewInvalidator(broker, cache, config.Namespace, logger, events...).WithKeys(keys)
+		lifecycle.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				return invalidator.Subscribe()
+			},
+			OnStop: func(ctx context.Context) error {
+				invalidator.Unsubscribe()
+				return nil
+			},
+		})
+	}
+}
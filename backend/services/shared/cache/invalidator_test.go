@@ -0,0 +1,96 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/stretchr/testify/assert"
+	"go-micro.dev/v4/broker"
+)
+
+func TestInvalidator(t *testing.T) {
+	ctx := context.Background()
+	mbroker := messaging.BrokerWithOptions{Broker: broker.NewMemoryBroker()}
+	assert.NoError(t, mbroker.Broker.Connect())
+	defer mbroker.Broker.Disconnect()
+
+	replicas := []Cache{NewMemoryCache(), NewMemoryCache()}
+	for _, replica := range replicas {
+		invalidator := NewInvalidator(mbroker, replica, "pipedrive", log.NewEmptyLogger(), request.EventUserUpdated)
+		assert.NoError(t, invalidator.Subscribe())
+		defer invalidator.Unsubscribe()
+
+		assert.NoError(t, replica.Put(ctx, "1:2", "user", time.Minute))
+		assert.NoError(t, replica.Put(ctx, "1:3", "user", time.Minute))
+	}
+
+	publisher := shared.NewEventPublisher(mbroker, &config.ServerConfig{Namespace: "pipedrive"}, log.NewEmptyLogger())
+
+	t.Run("drop entries on every replica", func(t *testing.T) {
+		publisher.Publish(request.EventUserUpdated, "1:2")
+		assert.Eventually(t, func() bool {
+			for _, replica := range replicas {
+				if _, _, err := replica.Get(ctx, "1:2"); err == nil {
+					return false
+				}
+			}
+
+			return true
+		}, time.Second, 10*time.Millisecond)
+
+		for _, replica := range replicas {
+			_, _, err := replica.Get(ctx, "1:3")
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("drop entries derived from an entity", func(t *testing.T) {
+		replica := NewMemoryCache()
+		invalidator := NewInvalidator(mbroker, replica, "pipedrive", log.NewEmptyLogger(), request.EventSettingsDeleted).
+			WithKeys(func(id string) []string { return []string{id, "health-" + id} })
+		assert.NoError(t, invalidator.Subscribe())
+		defer invalidator.Unsubscribe()
+
+		assert.NoError(t, replica.Put(ctx, "1", "settings", time.Minute))
+		assert.NoError(t, replica.Put(ctx, "health-1", "status", time.Minute))
+		publisher.Publish(request.EventSettingsDeleted, "1")
+		assert.Eventually(t, func() bool {
+			_, _, serr := replica.Get(ctx, "1")
+			_, _, herr := replica.Get(ctx, "health-1")
+			return serr != nil && herr != nil
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("ignore unsubscribed events", func(t *testing.T) {
+		publisher.Publish(request.EventSettingsUpdated, "1:3")
+		time.Sleep(50 * time.Millisecond)
+		for _, replica := range replicas {
+			_, _, err := replica.Get(ctx, "1:3")
+			assert.NoError(t, err)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memorySweepInterval = 1024
+
+type memoryEntry struct {
+	value     []byte
+	count     int64
+	expiresAt time.Time
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// MemoryCache keeps entries in process memory. Its invalidations only reach the
+// current replica.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	puts    int
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) (interface{}, time.Time, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && entry.expired(time.Now()) {
+		delete(c.entries, key)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		return nil, time.Time{}, ErrCacheMiss
+	}
+
+	res, err := decode(entry.value)
+	return res, entry.expiresAt, err
+}
+
+func (c *MemoryCache) Put(ctx context.Context, key string, val interface{}, d time.Duration) error {
+	if d < 0 {
+		return c.Delete(ctx, key)
+	}
+
+	buf, err := encode(val)
+	if err != nil {
+		return err
+	}
+
+	entry := memoryEntry{value: buf}
+	if d > 0 {
+		entry.expiresAt = time.Now().Add(d)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+	c.puts++
+	if c.puts%memorySweepInterval == 0 {
+		now := time.Now()
+		for k, e := range c.entries {
+			if e.expired(now) {
+				delete(c.entries, k)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	return c.Invalidate(ctx, key)
+}
+
+func (c *MemoryCache) Invalidate(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+
+	return nil
+}
+
+func (c *MemoryCache) Acquire(ctx context.Context, key string, d time.Duration) (bool, error) {
+	buf, err := encode(true)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if entry, ok := c.entries[key]; ok && !entry.expired(now) {
+		return false, nil
+	}
+
+	entry := memoryEntry{value: buf}
+	if d > 0 {
+		entry.expiresAt = now.Add(d)
+	}
+
+	c.entries[key] = entry
+	return true, nil
+}
+
+func (c *MemoryCache) Increment(ctx context.Context, key string, delta int64, d time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := c.entries[key]
+	if !ok || entry.expired(now) {
+		entry = memoryEntry{}
+		if d > 0 {
+			entry.expiresAt = now.Add(d)
+		}
+	}
+
+	entry.count += delta
+	c.entries[key] = entry
+	return entry.count, nil
+}
+
+func (c *MemoryCache) String() string {
+	return "memory"
+}
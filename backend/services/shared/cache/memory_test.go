@@ -0,0 +1,113 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/assert"
+)
+
+type cachedUser struct {
+	ID        string `json:"id" mapstructure:"id"`
+	ExpiresAt int64  `json:"expires_at" mapstructure:"expires_at"`
+}
+
+func testCache(t *testing.T, cache Cache) {
+	ctx := context.Background()
+
+	t.Run("get a missing entry", func(t *testing.T) {
+		_, _, err := cache.Get(ctx, "missing")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+
+	t.Run("put and get an entry", func(t *testing.T) {
+		assert.NoError(t, cache.Put(ctx, "user", cachedUser{ID: "1:2", ExpiresAt: 42}, time.Minute))
+		res, _, err := cache.Get(ctx, "user")
+		assert.NoError(t, err)
+
+		var user cachedUser
+		assert.NoError(t, mapstructure.Decode(res, &user))
+		assert.Equal(t, cachedUser{ID: "1:2", ExpiresAt: 42}, user)
+	})
+
+	t.Run("get an expired entry", func(t *testing.T) {
+		assert.NoError(t, cache.Put(ctx, "expiring", "value", 10*time.Millisecond))
+		time.Sleep(30 * time.Millisecond)
+		_, _, err := cache.Get(ctx, "expiring")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+
+	t.Run("put an already expired entry", func(t *testing.T) {
+		assert.NoError(t, cache.Put(ctx, "user", "value", -time.Second))
+		_, _, err := cache.Get(ctx, "user")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+
+	t.Run("invalidate entries", func(t *testing.T) {
+		assert.NoError(t, cache.Put(ctx, "first", "value", 0))
+		assert.NoError(t, cache.Put(ctx, "second", "value", 0))
+		assert.NoError(t, cache.Invalidate(ctx, "first", "second"))
+		_, _, err := cache.Get(ctx, "first")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+		_, _, err = cache.Get(ctx, "second")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+
+	t.Run("acquire a lease", func(t *testing.T) {
+		ok, err := cache.Acquire(ctx, "lease", 20*time.Millisecond)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = cache.Acquire(ctx, "lease", 20*time.Millisecond)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		time.Sleep(40 * time.Millisecond)
+		ok, err = cache.Acquire(ctx, "lease", 20*time.Millisecond)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("increment a counter", func(t *testing.T) {
+		count, err := cache.Increment(ctx, "counter", 1, 20*time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		count, err = cache.Increment(ctx, "counter", 1, 20*time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		count, err = cache.Increment(ctx, "counter", -1, 20*time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		time.Sleep(40 * time.Millisecond)
+		count, err = cache.Increment(ctx, "counter", 1, 20*time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func TestMemoryCache(t *testing.T) {
+	testCache(t, NewMemoryCache())
+}
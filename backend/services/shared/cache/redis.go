@@ -0,0 +1,125 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache keeps entries in a redis-protocol store shared by all replicas,
+// so that an invalidation on one replica is seen by every other one.
+type RedisCache struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func NewRedisCache(address, username, password string, database int, prefix string) *RedisCache {
+	return &RedisCache{
+		client: redis.NewClient(&redis.Options{
+			Addr:     address,
+			Username: username,
+			Password: password,
+			DB:       database,
+		}),
+		prefix: prefix,
+	}
+}
+
+func (c *RedisCache) key(key string) string {
+	return c.prefix + key
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) (interface{}, time.Time, error) {
+	buf, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, time.Time{}, ErrCacheMiss
+		}
+
+		return nil, time.Time{}, err
+	}
+
+	res, err := decode(buf)
+	return res, time.Time{}, err
+}
+
+func (c *RedisCache) Put(ctx context.Context, key string, val interface{}, d time.Duration) error {
+	if d < 0 {
+		return c.Delete(ctx, key)
+	}
+
+	buf, err := encode(val)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, c.key(key), buf, d).Err()
+}
+
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	return c.Invalidate(ctx, key)
+}
+
+func (c *RedisCache) Invalidate(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, c.key(key))
+	}
+
+	return c.client.Del(ctx, prefixed...).Err()
+}
+
+func (c *RedisCache) Acquire(ctx context.Context, key string, d time.Duration) (bool, error) {
+	buf, err := encode(true)
+	if err != nil {
+		return false, err
+	}
+
+	return c.client.SetNX(ctx, c.key(key), buf, d).Result()
+}
+
+// Increment runs in a transaction so that a counter never outlives its expiry.
+// PEXPIRE NX only starts the expiry of counters without one and needs redis 7.
+func (c *RedisCache) Increment(ctx context.Context, key string, delta int64, d time.Duration) (int64, error) {
+	var count *redis.IntCmd
+	if _, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.IncrBy(ctx, c.key(key), delta)
+		if d > 0 {
+			pipe.Do(ctx, "pexpire", c.key(key), d.Milliseconds(), "nx")
+		}
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return count.Val(), nil
+}
+
+func (c *RedisCache) String() string {
+	return "redis"
+}
@@ -0,0 +1,278 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// redisStandIn is a minimal in-process redis-protocol server that understands
+// the commands the cache sends.
+type redisStandIn struct {
+	listener net.Listener
+	mu       sync.Mutex
+	values   map[string]string
+	expires  map[string]time.Time
+}
+
+func newRedisStandIn(t *testing.T) *redisStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &redisStandIn{
+		listener: listener,
+		values:   make(map[string]string),
+		expires:  make(map[string]time.Time),
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go server.serve(conn)
+		}
+	}()
+
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *redisStandIn) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *redisStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	var queued [][]string
+	multi := false
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		var reply string
+		switch command := strings.ToUpper(args[0]); {
+		case command == "MULTI":
+			multi, queued, reply = true, nil, "+OK\r\n"
+		case command == "EXEC":
+			reply = s.transact(queued)
+			multi, queued = false, nil
+		case multi:
+			queued, reply = append(queued, args), "+QUEUED\r\n"
+		default:
+			reply = s.execute(args)
+		}
+
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (s *redisStandIn) transact(commands [][]string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replies := fmt.Sprintf("*%d\r\n", len(commands))
+	for _, args := range commands {
+		replies += s.run(args)
+	}
+
+	return replies
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+
+		args = append(args, string(buf[:size]))
+	}
+
+	return args, nil
+}
+
+func (s *redisStandIn) execute(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.run(args)
+}
+
+func (s *redisStandIn) run(args []string) string {
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "CLIENT", "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		val, ok := s.values[args[1]]
+		if expires, set := s.expires[args[1]]; set && !time.Now().Before(expires) {
+			delete(s.values, args[1])
+			delete(s.expires, args[1])
+			ok = false
+		}
+
+		if !ok {
+			return "$-1\r\n"
+		}
+
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(val), val)
+	case "SET":
+		var ttl time.Duration
+		var nx bool
+		for i := 3; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "NX":
+				nx = true
+			case "EX", "PX":
+				value, _ := strconv.Atoi(args[i+1])
+				unit := time.Millisecond
+				if strings.EqualFold(args[i], "ex") {
+					unit = time.Second
+				}
+
+				ttl = time.Duration(value) * unit
+				i++
+			}
+		}
+
+		if _, ok := s.values[args[1]]; ok && nx {
+			if expires, set := s.expires[args[1]]; !set || time.Now().Before(expires) {
+				return "$-1\r\n"
+			}
+		}
+
+		s.values[args[1]] = args[2]
+		delete(s.expires, args[1])
+		if ttl > 0 {
+			s.expires[args[1]] = time.Now().Add(ttl)
+		}
+
+		return "+OK\r\n"
+	case "INCRBY":
+		if expires, set := s.expires[args[1]]; set && !time.Now().Before(expires) {
+			delete(s.values, args[1])
+			delete(s.expires, args[1])
+		}
+
+		count, _ := strconv.ParseInt(s.values[args[1]], 10, 64)
+		delta, _ := strconv.ParseInt(args[2], 10, 64)
+		count += delta
+		s.values[args[1]] = strconv.FormatInt(count, 10)
+		return fmt.Sprintf(":%d\r\n", count)
+	case "PEXPIRE":
+		if _, ok := s.values[args[1]]; !ok {
+			return ":0\r\n"
+		}
+
+		if _, set := s.expires[args[1]]; set && len(args) > 3 && strings.EqualFold(args[3], "nx") {
+			return ":0\r\n"
+		}
+
+		value, _ := strconv.Atoi(args[2])
+		s.expires[args[1]] = time.Now().Add(time.Duration(value) * time.Millisecond)
+		return ":1\r\n"
+	case "DEL":
+		removed := 0
+		for _, key := range args[1:] {
+			if _, ok := s.values[key]; ok {
+				removed++
+			}
+
+			delete(s.values, key)
+			delete(s.expires, key)
+		}
+
+		return fmt.Sprintf(":%d\r\n", removed)
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+func TestRedisCache(t *testing.T) {
+	server := newRedisStandIn(t)
+	testCache(t, NewRedisCache(server.Addr(), "", "", 0, "pipedrive:auth:"))
+
+	t.Run("share entries between replicas", func(t *testing.T) {
+		ctx := context.Background()
+		first := NewRedisCache(server.Addr(), "", "", 0, "pipedrive:auth:")
+		second := NewRedisCache(server.Addr(), "", "", 0, "pipedrive:auth:")
+
+		assert.NoError(t, first.Put(ctx, "shared", "value", time.Minute))
+		res, _, err := second.Get(ctx, "shared")
+		assert.NoError(t, err)
+		assert.Equal(t, "value", res)
+
+		assert.NoError(t, second.Invalidate(ctx, "shared"))
+		_, _, err = first.Get(ctx, "shared")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+
+	t.Run("keep services apart", func(t *testing.T) {
+		ctx := context.Background()
+		auth := NewRedisCache(server.Addr(), "", "", 0, "pipedrive:auth:")
+		settings := NewRedisCache(server.Addr(), "", "", 0, "pipedrive:settings:")
+
+		assert.NoError(t, auth.Put(ctx, "1", "user", time.Minute))
+		_, _, err := settings.Get(ctx, "1")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+}
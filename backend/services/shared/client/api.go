@@ -23,7 +23,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
@@ -36,6 +35,9 @@ import (
 
 type PipedriveApiClient struct {
 	client *resty.Client
+	// uploader does not retry on its own since multipart readers are consumed
+	// by the first attempt. Uploads are retried from spooled transfers instead.
+	uploader *resty.Client
 }
 
 func NewPipedriveApiClient() PipedriveApiClient {
@@ -49,6 +51,8 @@ func NewPipedriveApiClient() PipedriveApiClient {
 		ExpectContinueTimeout: 1 * time.Second,
 	})
 	return PipedriveApiClient{
+		uploader: resty.NewWithClient(otelClient).
+			SetLogger(log.NewEmptyLogger()),
 		client: resty.NewWithClient(otelClient).
 			SetRetryCount(3).
 			SetRetryWaitTime(120 * time.Millisecond).
@@ -96,14 +100,14 @@ func (p *PipedriveApiClient) GetMe(ctx context.Context, token model.Token) (mode
 	return usr, nil
 }
 
-func (p *PipedriveApiClient) UpdateFile(ctx context.Context, id, name string, token model.Token) error {
+func (p *PipedriveApiClient) getEntity(ctx context.Context, action, path string, token model.Token, entity interface{}) error {
+	var resp interface{}
+
 	res, err := p.client.R().
 		SetContext(ctx).
 		SetAuthToken(token.AccessToken).
-		SetFormData(map[string]string{
-			"name": name,
-		}).
-		Put(fmt.Sprintf("%s/api/v1/files/%s", token.ApiDomain, id))
+		SetResult(&resp).
+		Get(fmt.Sprintf("%s/api/v1/%s", token.ApiDomain, path))
 
 	if err != nil {
 		return err
@@ -111,90 +115,125 @@ func (p *PipedriveApiClient) UpdateFile(ctx context.Context, id, name string, to
 
 	if res.StatusCode() != http.StatusOK {
 		return &UnexpectedStatusCodeError{
-			Action: "update file",
+			Action: action,
 			Code:   res.StatusCode(),
 		}
 	}
 
-	return nil
+	m, ok := resp.(map[string]interface{})
+	if !ok {
+		return &UnexpectedStatusCodeError{
+			Action: action,
+			Code:   http.StatusInternalServerError,
+		}
+	}
+
+	return mapstructure.Decode(m["data"], entity)
 }
 
-func (c *PipedriveApiClient) ValidateFileSize(ctx context.Context, limit int64, url string) (int64, error) {
-	headResp, err := c.client.R().
-		SetContext(ctx).
-		Head(url)
+func (p *PipedriveApiClient) GetDeal(ctx context.Context, id string, token model.Token) (model.Deal, error) {
+	var deal model.Deal
+	err := p.getEntity(ctx, "get deal", fmt.Sprintf("deals/%s", id), token, &deal)
+	return deal, err
+}
 
-	if err != nil {
-		return 0, err
-	}
+func (p *PipedriveApiClient) GetPerson(ctx context.Context, id int, token model.Token) (model.Person, error) {
+	var person model.Person
+	err := p.getEntity(ctx, "get person", fmt.Sprintf("persons/%d", id), token, &person)
+	return person, err
+}
 
-	if val, err := strconv.ParseInt(headResp.Header().Get("Content-Length"), 10, 0); val > limit || err != nil {
-		return 0, ErrInvalidContentLength
-	} else {
-		return val, nil
-	}
+func (p *PipedriveApiClient) GetOrganization(ctx context.Context, id int, token model.Token) (model.Organization, error) {
+	var org model.Organization
+	err := p.getEntity(ctx, "get organization", fmt.Sprintf("organizations/%d", id), token, &org)
+	return org, err
 }
 
-func (p PipedriveApiClient) getFile(ctx context.Context, url string) (io.ReadCloser, error) {
-	fileResp, err := p.client.R().
+func (p *PipedriveApiClient) UpdateFile(ctx context.Context, id, name string, token model.Token) error {
+	res, err := p.client.R().
 		SetContext(ctx).
-		SetDoNotParseResponse(true).
-		Get(url)
+		SetAuthToken(token.AccessToken).
+		SetFormData(map[string]string{
+			"name": name,
+		}).
+		Put(fmt.Sprintf("%s/api/v1/files/%s", token.ApiDomain, id))
+
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if fileResp.RawResponse.StatusCode != http.StatusOK {
-		fileResp.RawBody().Close()
-		return nil, fmt.Errorf("unexpected status code: %d", fileResp.RawResponse.StatusCode)
+	if res.StatusCode() != http.StatusOK {
+		return &UnexpectedStatusCodeError{
+			Action: "update file",
+			Code:   res.StatusCode(),
+		}
 	}
 
-	return fileResp.RawBody(), nil
+	return nil
 }
 
-func (p *PipedriveApiClient) UploadFile(ctx context.Context, url, deal, fileID, filename string, size int64, token model.Token) error {
-	if err := p.UpdateFile(ctx, fileID, filename, token); err != nil {
-		return err
-	}
-
-	file, err := p.getFile(ctx, url)
-	if err != nil {
-		return err
+func (p *PipedriveApiClient) postFile(ctx context.Context, parent model.Parent, filename string, file io.Reader, token model.Token) (response.AddFileResponse, error) {
+	var body response.AddFileResponse
+	if err := parent.Validate(); err != nil {
+		return body, err
 	}
-	defer file.Close()
 
-	_, err = p.client.R().
+	res, err := p.uploader.R().
+		SetResult(&body).
 		SetContext(ctx).
 		SetAuthToken(token.AccessToken).
 		SetFileReader("file", filename, file).
 		SetFormData(map[string]string{
-			"deal_id": deal,
+			parent.Field(): parent.ID,
 		}).
 		Post(fmt.Sprintf("%s/api/v1/files", token.ApiDomain))
 
 	if err != nil {
-		return err
+		return body, err
 	}
 
-	return nil
+	if res.StatusCode() != http.StatusOK && res.StatusCode() != http.StatusCreated {
+		return body, &UnexpectedStatusCodeError{
+			Action: "upload file",
+			Code:   res.StatusCode(),
+		}
+	}
+
+	return body, nil
 }
 
-func (p *PipedriveApiClient) CreateFile(ctx context.Context, deal, filename string, file io.ReadCloser, token model.Token) (response.AddFileResponse, error) {
+// CreateFileFromURL attaches a file located at url to the parent as a new file.
+func (p *PipedriveApiClient) CreateFileFromURL(ctx context.Context, url string, parent model.Parent, filename string, limit int64, token model.Token) (response.AddFileResponse, error) {
 	var body response.AddFileResponse
+	transfer, err := p.DownloadFile(ctx, url, limit)
+	if err != nil {
+		return body, err
+	}
+	defer transfer.Close()
 
-	_, err := p.client.R().
-		SetResult(&body).
+	return p.UploadFile(ctx, transfer, parent, filename, token)
+}
+
+func (p *PipedriveApiClient) CreateFile(ctx context.Context, parent model.Parent, filename string, file io.ReadCloser, token model.Token) (response.AddFileResponse, error) {
+	return p.postFile(ctx, parent, filename, file, token)
+}
+
+func (p *PipedriveApiClient) DeleteFile(ctx context.Context, id string, token model.Token) error {
+	res, err := p.client.R().
 		SetContext(ctx).
 		SetAuthToken(token.AccessToken).
-		SetFileReader("file", filename, file).
-		SetFormData(map[string]string{
-			"deal_id": deal,
-		}).
-		Post(fmt.Sprintf("%s/api/v1/files", token.ApiDomain))
+		Delete(fmt.Sprintf("%s/api/v1/files/%s", token.ApiDomain, id))
 
 	if err != nil {
-		return body, err
+		return err
 	}
 
-	return body, nil
+	if res.StatusCode() != http.StatusOK {
+		return &UnexpectedStatusCodeError{
+			Action: "delete file",
+			Code:   res.StatusCode(),
+		}
+	}
+
+	return nil
 }
@@ -63,6 +63,11 @@ func NewPipedriveAuthClient(credentials *oauth2.Config) PipedriveAuthClient {
 	}
 }
 
+type oauthError struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
 func (c PipedriveAuthClient) GetAccessToken(ctx context.Context, code, redirectURI string) (model.Token, error) {
 	var resp model.Token
 	if _, err := url.ParseRequestURI(redirectURI); err != nil {
@@ -97,6 +102,7 @@ func (c PipedriveAuthClient) GetAccessToken(ctx context.Context, code, redirectU
 
 func (c PipedriveAuthClient) RefreshAccessToken(ctx context.Context, refreshToken string) (model.Token, error) {
 	var resp model.Token
+	var oerr oauthError
 
 	res, err := c.client.R().
 		SetHeader("Content-Type", "application/x-www-form-urlencoded").
@@ -107,12 +113,19 @@ func (c PipedriveAuthClient) RefreshAccessToken(ctx context.Context, refreshToke
 		}.Encode())).
 		SetBasicAuth(c.clientID, c.clientSecret).
 		SetResult(&resp).
+		SetError(&oerr).
 		Post("/oauth/token")
 
 	if err != nil {
 		return resp, err
 	}
 
+	// Only a rejected grant means the token is gone for good. Other failures,
+	// e.g. a 401 for rotated client credentials, must not revoke users.
+	if res.StatusCode() == http.StatusBadRequest && oerr.Error == "invalid_grant" {
+		return resp, ErrRefreshTokenRevoked
+	}
+
 	if res.StatusCode() != http.StatusOK {
 		return resp, &UnexpectedStatusCodeError{
 			Action: "refresh access token",
@@ -0,0 +1,67 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestRefreshAccessToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    int
+		body    string
+		revoked bool
+	}{
+		{name: "revoked refresh token", code: http.StatusBadRequest, body: `{"error":"invalid_grant"}`, revoked: true},
+		{name: "malformed request", code: http.StatusBadRequest, body: `{"error":"invalid_request"}`},
+		{name: "invalid client credentials", code: http.StatusUnauthorized, body: `{"error":"invalid_client"}`},
+		{name: "unavailable token endpoint", code: http.StatusServiceUnavailable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				rw.Header().Set("Content-Type", "application/json")
+				rw.WriteHeader(test.code)
+				rw.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			client := NewPipedriveAuthClient(&oauth2.Config{ClientID: "mock", ClientSecret: "mock"})
+			client.client.SetBaseURL(server.URL)
+
+			_, err := client.RefreshAccessToken(context.Background(), "mock")
+			if test.revoked {
+				assert.ErrorIs(t, err, ErrRefreshTokenRevoked)
+				return
+			}
+
+			var serr *UnexpectedStatusCodeError
+			assert.ErrorAs(t, err, &serr)
+			assert.Equal(t, test.code, serr.Code)
+		})
+	}
+}
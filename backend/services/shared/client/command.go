@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	neturl "net/url"
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
@@ -30,11 +31,46 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
 	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
 	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
-var ErrCommandServiceError = errors.New("got a command service error 1 status")
+var ErrCommandServiceError = errors.New("got a command service error status")
+
+// Command service error codes.
+const (
+	CommandNoError         = 0
+	CommandKeyNotFound     = 1
+	CommandInvalidCallback = 2
+	CommandInternalError   = 3
+	CommandNoChanges       = 4
+	CommandInvalidCommand  = 5
+	CommandInvalidToken    = 6
+)
+
+type CommandServiceError struct {
+	Command string
+	Code    int
+}
+
+func (e *CommandServiceError) Error() string {
+	return fmt.Sprintf("could not perform %s command. Command service error: %d", e.Command, e.Code)
+}
+
+func (e *CommandServiceError) Unwrap() error {
+	return ErrCommandServiceError
+}
+
+// IsCommandError reports whether err is a command service error with the given code.
+func IsCommandError(err error, code int) bool {
+	var cerr *CommandServiceError
+	return errors.As(err, &cerr) && cerr.Code == code
+}
+
+type commandResult interface {
+	Code() int
+}
 
 type CommandClient struct {
 	client     *resty.Client
@@ -64,32 +100,101 @@ func NewCommandClient(jwtManager crypto.JwtManager) CommandClient {
 	}
 }
 
-func (p *CommandClient) License(ctx context.Context, url, secret string) error {
-	var resp response.BaseCommandResponse
-
-	token, err := p.jwtManager.Sign(secret, request.BaseCommandRequest{
-		C: "version",
-	})
-
+func (p *CommandClient) command(ctx context.Context, url, secret, header string, payload request.CommandRequest, result commandResult) error {
+	payload.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Minute))
+	token, err := p.jwtManager.Sign(secret, payload)
 	if err != nil {
 		return err
 	}
 
-	res, err := p.client.R().
+	req := p.client.R().
 		SetContext(ctx).
 		SetBody(request.TokenCommandRequest{
 			Token: token,
 		}).
-		SetResult(&resp).
-		Post(fmt.Sprintf("%scommand?shardkey=%s", url, uuid.New().String()))
+		SetResult(result)
+
+	// Servers configured for header-only tokens ignore the body token.
+	if header != "" {
+		htoken, err := signHeaderToken(p.jwtManager, secret, payload)
+		if err != nil {
+			return err
+		}
+
+		req.SetHeader(header, htoken)
+	}
+
+	res, err := req.Post(fmt.Sprintf("%scommand?shardkey=%s", url, shardKey(payload.Key)))
 
 	if err != nil {
 		return err
 	}
 
-	if res.StatusCode() >= 300 || resp.Error != 0 {
-		return ErrCommandServiceError
+	if res.StatusCode() >= 300 {
+		return &CommandServiceError{Command: payload.C, Code: CommandInternalError}
+	}
+
+	if code := result.Code(); code != CommandNoError {
+		return &CommandServiceError{Command: payload.C, Code: code}
 	}
 
 	return nil
 }
+
+// shardKey routes document commands to the shard that serves the document.
+func shardKey(key string) string {
+	if key != "" {
+		return neturl.QueryEscape(key)
+	}
+
+	return uuid.New().String()
+}
+
+// Version returns the document server version. It doubles as a credentials check.
+func (p *CommandClient) Version(ctx context.Context, url, secret, header string) (string, error) {
+	var resp response.VersionCommandResponse
+	if err := p.command(ctx, url, secret, header, request.CommandRequest{C: "version"}, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Version, nil
+}
+
+// License returns the document server license with its seat usage.
+func (p *CommandClient) License(ctx context.Context, url, secret, header string) (response.LicenseCommandResponse, error) {
+	var resp response.LicenseCommandResponse
+	err := p.command(ctx, url, secret, header, request.CommandRequest{C: "license"}, &resp)
+	return resp, err
+}
+
+// Info returns the users connected to the document with the given key.
+func (p *CommandClient) Info(ctx context.Context, url, secret, header, key string) (response.InfoCommandResponse, error) {
+	var resp response.InfoCommandResponse
+	err := p.command(ctx, url, secret, header, request.CommandRequest{C: "info", Key: key}, &resp)
+	return resp, err
+}
+
+// Drop disconnects the given users from the document editing session.
+func (p *CommandClient) Drop(ctx context.Context, url, secret, header, key string, users []string) (response.KeyCommandResponse, error) {
+	var resp response.KeyCommandResponse
+	err := p.command(ctx, url, secret, header, request.CommandRequest{C: "drop", Key: key, Users: users}, &resp)
+	return resp, err
+}
+
+// ForceSave saves the document without closing the editing session.
+func (p *CommandClient) ForceSave(ctx context.Context, url, secret, header, key, userdata string) (response.KeyCommandResponse, error) {
+	var resp response.KeyCommandResponse
+	err := p.command(ctx, url, secret, header, request.CommandRequest{C: "forcesave", Key: key, UserData: userdata}, &resp)
+	return resp, err
+}
+
+// Meta renames the document for every user in the editing session.
+func (p *CommandClient) Meta(ctx context.Context, url, secret, header, key, title string) (response.KeyCommandResponse, error) {
+	var resp response.KeyCommandResponse
+	err := p.command(ctx, url, secret, header, request.CommandRequest{
+		C:    "meta",
+		Key:  key,
+		Meta: &request.CommandMeta{Title: title},
+	}, &resp)
+	return resp, err
+}
@@ -0,0 +1,131 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/stretchr/testify/assert"
+)
+
+const commandSecret = "secret"
+
+func TestCommandClient(t *testing.T) {
+	jwtManager := crypto.NewJwtManager(&config.CryptoConfig{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		var body request.TokenCommandRequest
+		var cmd request.CommandRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || jwtManager.Verify(commandSecret, body.Token, &cmd) != nil {
+			rw.Write([]byte(`{"error":6}`))
+			return
+		}
+
+		switch cmd.C {
+		case "version":
+			rw.Write([]byte(`{"error":0,"version":"8.2.0.143"}`))
+		case "license":
+			rw.Write([]byte(`{"error":0,"license":{"end_date":"2030-01-01T00:00:00.000Z","users_count":50},"server":{"buildVersion":"8.2.0"},"quota":{"edit":{"connectionsCount":3,"usersCount":{"unique":2}}}}`))
+		case "info":
+			rw.Write([]byte(`{"error":0,"key":"` + cmd.Key + `","users":["1","2"]}`))
+		case "forcesave":
+			rw.Write([]byte(`{"error":4,"key":"` + cmd.Key + `"}`))
+		case "meta":
+			if cmd.Meta == nil || cmd.Meta.Title == "" {
+				rw.Write([]byte(`{"error":5}`))
+				return
+			}
+			rw.Write([]byte(`{"error":0,"key":"` + cmd.Key + `"}`))
+		default:
+			rw.Write([]byte(`{"error":1}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewCommandClient(jwtManager)
+	url := server.URL + "/"
+
+	t.Run("get version", func(t *testing.T) {
+		version, err := client.Version(context.Background(), url, commandSecret, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "8.2.0.143", version)
+	})
+
+	t.Run("get license", func(t *testing.T) {
+		license, err := client.License(context.Background(), url, commandSecret, "")
+		assert.NoError(t, err)
+		assert.Equal(t, 50, license.License.UsersCount)
+		assert.Equal(t, 2, license.Quota.Edit.UsersCount.Unique)
+	})
+
+	t.Run("get session info", func(t *testing.T) {
+		info, err := client.Info(context.Background(), url, commandSecret, "", "mock")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, info.Users)
+	})
+
+	t.Run("force save without changes", func(t *testing.T) {
+		_, err := client.ForceSave(context.Background(), url, commandSecret, "", "mock", "")
+		assert.ErrorIs(t, err, ErrCommandServiceError)
+		assert.True(t, IsCommandError(err, CommandNoChanges))
+	})
+
+	t.Run("rename a document", func(t *testing.T) {
+		res, err := client.Meta(context.Background(), url, commandSecret, "", "mock", "renamed.docx")
+		assert.NoError(t, err)
+		assert.Equal(t, "mock", res.Key)
+	})
+
+	t.Run("drop users of an unknown document", func(t *testing.T) {
+		_, err := client.Drop(context.Background(), url, commandSecret, "", "mock", []string{"1"})
+		assert.True(t, IsCommandError(err, CommandKeyNotFound))
+	})
+
+	t.Run("send a command token in a header", func(t *testing.T) {
+		var header string
+		hserver := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			header = r.Header.Get("AuthorizationJwt")
+			rw.Header().Set("Content-Type", "application/json")
+			rw.Write([]byte(`{"error":0,"version":"8.2.0.143"}`))
+		}))
+		defer hserver.Close()
+
+		_, err := client.Version(context.Background(), hserver.URL+"/", commandSecret, "AuthorizationJwt")
+		assert.NoError(t, err)
+
+		var claims struct {
+			Payload request.CommandRequest `mapstructure:"payload"`
+		}
+
+		assert.NoError(t, jwtManager.Verify(commandSecret, ExtractHeaderToken(header), &claims))
+		assert.Equal(t, "version", claims.Payload.C)
+	})
+
+	t.Run("send a command with an invalid secret", func(t *testing.T) {
+		_, err := client.Version(context.Background(), url, "invalid", "")
+		assert.True(t, IsCommandError(err, CommandInvalidToken))
+	})
+}
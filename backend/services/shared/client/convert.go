@@ -0,0 +1,115 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+type ConvertServiceError struct {
+	Code int
+}
+
+func (e *ConvertServiceError) Error() string {
+	return fmt.Sprintf("could not convert document. Convert service error: %d", e.Code)
+}
+
+type ConvertClient struct {
+	client     *resty.Client
+	jwtManager crypto.JwtManager
+}
+
+func NewConvertClient(jwtManager crypto.JwtManager) ConvertClient {
+	otelClient := otelhttp.DefaultClient
+	otelClient.Transport = otelhttp.NewTransport(&http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   30 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	})
+	return ConvertClient{
+		client: resty.NewWithClient(otelClient).
+			SetRetryCount(0).
+			SetRetryWaitTime(120 * time.Millisecond).
+			SetRetryMaxWaitTime(900 * time.Millisecond).
+			SetLogger(log.NewEmptyLogger()).
+			AddRetryCondition(func(r *resty.Response, err error) bool {
+				return r.StatusCode() == http.StatusTooManyRequests
+			}),
+		jwtManager: jwtManager,
+	}
+}
+
+// Convert sends a single conversion request. Async requests should be repeated
+// with the same key until the response reports the end of conversion.
+func (c *ConvertClient) Convert(ctx context.Context, url, secret, header string, payload request.ConvertRequest) (response.ConvertResponse, error) {
+	var resp response.ConvertResponse
+
+	token, err := c.jwtManager.Sign(secret, payload)
+	if err != nil {
+		return resp, err
+	}
+
+	req := c.client.R().
+		SetContext(ctx).
+		SetHeader("Accept", "application/json").
+		SetResult(&resp)
+
+	// Servers configured for header-only tokens ignore the body token.
+	if header != "" {
+		htoken, err := signHeaderToken(c.jwtManager, secret, payload)
+		if err != nil {
+			return resp, err
+		}
+
+		req.SetHeader(header, htoken)
+	}
+
+	payload.Token = token
+	res, err := req.SetBody(payload).Post(fmt.Sprintf("%sConvertService.ashx", url))
+
+	if err != nil {
+		return resp, err
+	}
+
+	if res.StatusCode() >= 300 {
+		return resp, &UnexpectedStatusCodeError{
+			Action: "convert document",
+			Code:   res.StatusCode(),
+		}
+	}
+
+	if resp.Error != 0 {
+		return resp, &ConvertServiceError{Code: resp.Error}
+	}
+
+	return resp, nil
+}
@@ -26,6 +26,8 @@ import (
 var (
 	ErrInvalidUrlFormat     = errors.New("url is not valid")
 	ErrInvalidContentLength = errors.New("could not perform api actions due to exceeding content-length")
+	ErrRefreshTokenRevoked  = errors.New("refresh token has been revoked")
+	ErrIncompleteTransfer   = errors.New("file transfer has ended before content-length bytes were read")
 )
 
 type UnexpectedStatusCodeError struct {
@@ -0,0 +1,65 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const bearerPrefix = "Bearer "
+
+// signHeaderToken signs a token for the document server's JWT header. Unlike
+// body tokens, header tokens carry the request under the payload claim.
+func signHeaderToken(jwtManager crypto.JwtManager, secret string, payload jwt.Claims) (string, error) {
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(buf, &claims); err != nil {
+		return "", err
+	}
+
+	wrapped := jwt.MapClaims{"payload": claims}
+	if exp, ok := claims["exp"]; ok {
+		wrapped["exp"] = exp
+	}
+
+	token, err := jwtManager.Sign(secret, wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	return bearerPrefix + token, nil
+}
+
+// ExtractHeaderToken returns the token from a JWT header value with an optional Bearer scheme.
+func ExtractHeaderToken(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= len(bearerPrefix) && strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) {
+		return strings.TrimSpace(value[len(bearerPrefix):])
+	}
+
+	return value
+}
@@ -0,0 +1,78 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package model
+
+type Relation struct {
+	ID   int    `json:"value" mapstructure:"value"`
+	Name string `json:"name" mapstructure:"name"`
+}
+
+type Deal struct {
+	ID                int      `json:"id" mapstructure:"id"`
+	Title             string   `json:"title" mapstructure:"title"`
+	Value             float64  `json:"value" mapstructure:"value"`
+	Currency          string   `json:"currency" mapstructure:"currency"`
+	Status            string   `json:"status" mapstructure:"status"`
+	ExpectedCloseDate string   `json:"expected_close_date" mapstructure:"expected_close_date"`
+	Person            Relation `json:"person_id" mapstructure:"person_id"`
+	Organization      Relation `json:"org_id" mapstructure:"org_id"`
+}
+
+type Contact struct {
+	Label   string `json:"label" mapstructure:"label"`
+	Value   string `json:"value" mapstructure:"value"`
+	Primary bool   `json:"primary" mapstructure:"primary"`
+}
+
+type Person struct {
+	ID    int       `json:"id" mapstructure:"id"`
+	Name  string    `json:"name" mapstructure:"name"`
+	Email []Contact `json:"email" mapstructure:"email"`
+	Phone []Contact `json:"phone" mapstructure:"phone"`
+}
+
+// PrimaryEmail returns the primary email address or the first one available.
+func (p Person) PrimaryEmail() string {
+	return primaryContact(p.Email)
+}
+
+// PrimaryPhone returns the primary phone number or the first one available.
+func (p Person) PrimaryPhone() string {
+	return primaryContact(p.Phone)
+}
+
+func primaryContact(contacts []Contact) string {
+	for _, contact := range contacts {
+		if contact.Primary {
+			return contact.Value
+		}
+	}
+
+	if len(contacts) > 0 {
+		return contacts[0].Value
+	}
+
+	return ""
+}
+
+type Organization struct {
+	ID      int    `json:"id" mapstructure:"id"`
+	Name    string `json:"name" mapstructure:"name"`
+	Address string `json:"address" mapstructure:"address"`
+}
@@ -0,0 +1,139 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidParent = errors.New("invalid pipedrive file parent")
+
+const (
+	ParentDeal         = "deal"
+	ParentPerson       = "person"
+	ParentOrganization = "org"
+	ParentLead         = "lead"
+	ParentProduct      = "product"
+	ParentActivity     = "activity"
+)
+
+const parentSeparator = ":"
+
+// Pipedrive files form fields of the entities files may be attached to.
+var parentFields = map[string]string{
+	ParentDeal:         "deal_id",
+	ParentPerson:       "person_id",
+	ParentOrganization: "org_id",
+	ParentLead:         "lead_id",
+	ParentProduct:      "product_id",
+	ParentActivity:     "activity_id",
+}
+
+var (
+	parentIDPattern   = regexp.MustCompile(`^[0-9]+$`)
+	parentLeadPattern = regexp.MustCompile(`^[0-9a-fA-F-]+$`)
+)
+
+// Parent is a pipedrive entity a file is attached to. It is encoded as
+// type:id, a bare id is treated as a deal for compatibility.
+type Parent struct {
+	Type string
+	ID   string
+}
+
+func NewParent(ptype, id string) Parent {
+	return Parent{
+		Type: ptype,
+		ID:   id,
+	}
+}
+
+func ParseParent(value string) (Parent, error) {
+	var parent Parent
+	err := parent.UnmarshalText([]byte(value))
+	return parent, err
+}
+
+func (p *Parent) Validate() error {
+	p.Type = strings.ToLower(strings.TrimSpace(p.Type))
+	p.ID = strings.TrimSpace(p.ID)
+
+	if _, ok := parentFields[p.Type]; !ok {
+		return ErrInvalidParent
+	}
+
+	pattern := parentIDPattern
+	if p.Type == ParentLead {
+		pattern = parentLeadPattern
+	}
+
+	if len(p.ID) > 64 || !pattern.MatchString(p.ID) {
+		return ErrInvalidParent
+	}
+
+	return nil
+}
+
+// Field returns the pipedrive form field used to attach a file to the parent.
+func (p Parent) Field() string {
+	return parentFields[p.Type]
+}
+
+// DealID returns the parent id when the parent is a deal.
+func (p Parent) DealID() string {
+	if p.Type == ParentDeal {
+		return p.ID
+	}
+
+	return ""
+}
+
+func (p Parent) String() string {
+	if p.Type == "" && p.ID == "" {
+		return ""
+	}
+
+	return p.Type + parentSeparator + p.ID
+}
+
+func (p Parent) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Parent) UnmarshalText(text []byte) error {
+	value := strings.TrimSpace(string(text))
+	if value == "" {
+		*p = Parent{}
+		return nil
+	}
+
+	parent := NewParent(ParentDeal, value)
+	if ptype, id, ok := strings.Cut(value, parentSeparator); ok {
+		parent = NewParent(ptype, id)
+	}
+
+	if err := parent.Validate(); err != nil {
+		return err
+	}
+
+	*p = parent
+	return nil
+}
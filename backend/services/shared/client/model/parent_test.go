@@ -0,0 +1,64 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParent(t *testing.T) {
+	t.Run("parse typed parent", func(t *testing.T) {
+		parent, err := ParseParent("person:42")
+		assert.NoError(t, err)
+		assert.Equal(t, NewParent(ParentPerson, "42"), parent)
+		assert.Equal(t, "person_id", parent.Field())
+		assert.Empty(t, parent.DealID())
+	})
+
+	t.Run("parse legacy deal id", func(t *testing.T) {
+		parent, err := ParseParent("7")
+		assert.NoError(t, err)
+		assert.Equal(t, "deal:7", parent.String())
+		assert.Equal(t, "7", parent.DealID())
+	})
+
+	t.Run("parse lead parent", func(t *testing.T) {
+		parent, err := ParseParent("lead:adf21080-0e10-11eb-879b-05d71fb426ec")
+		assert.NoError(t, err)
+		assert.Equal(t, "lead_id", parent.Field())
+	})
+
+	t.Run("parse invalid parents", func(t *testing.T) {
+		for _, value := range []string{"mock:1", "deal:", "deal:1&fid=2", "person:abc"} {
+			_, err := ParseParent(value)
+			assert.ErrorIs(t, err, ErrInvalidParent, value)
+		}
+	})
+
+	t.Run("encode parent as json string", func(t *testing.T) {
+		buf, err := json.Marshal(struct {
+			Parent Parent `json:"parent"`
+		}{Parent: NewParent(ParentOrganization, "3")})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"parent":"org:3"}`, string(buf))
+	})
+}
@@ -0,0 +1,166 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/response"
+)
+
+const (
+	uploadAttempts = 3
+	uploadBackoff  = 500 * time.Millisecond
+)
+
+// Transfer is a downloaded file spooled to disk so that its upload can be retried.
+type Transfer struct {
+	Path     string
+	Size     int64
+	Checksum string
+}
+
+func (t Transfer) Open() (*os.File, error) {
+	return os.Open(t.Path)
+}
+
+// Close removes the spooled file.
+func (t Transfer) Close() error {
+	if t.Path == "" {
+		return nil
+	}
+
+	if err := os.Remove(t.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// DownloadFile spools the file located at url to disk, computing its sha-256
+// sum on the way. The limit is enforced on the body itself since document
+// servers may omit or misreport the content length.
+func (p *PipedriveApiClient) DownloadFile(ctx context.Context, url string, limit int64) (Transfer, error) {
+	var transfer Transfer
+	res, err := p.client.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		Get(url)
+	if err != nil {
+		return transfer, err
+	}
+
+	body := res.RawBody()
+	defer body.Close()
+
+	if res.RawResponse.StatusCode != http.StatusOK {
+		return transfer, &UnexpectedStatusCodeError{
+			Action: "download file",
+			Code:   res.RawResponse.StatusCode,
+		}
+	}
+
+	length := res.RawResponse.ContentLength
+	if length > limit {
+		return transfer, ErrInvalidContentLength
+	}
+
+	file, err := os.CreateTemp("", "pipedrive-transfer-*")
+	if err != nil {
+		return transfer, err
+	}
+
+	transfer.Path = file.Name()
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), io.LimitReader(body, limit+1))
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+
+	switch {
+	case err != nil:
+	case size > limit:
+		err = ErrInvalidContentLength
+	case length >= 0 && size != length:
+		err = ErrIncompleteTransfer
+	}
+
+	if err != nil {
+		transfer.Close()
+		return Transfer{}, err
+	}
+
+	transfer.Size = size
+	transfer.Checksum = hex.EncodeToString(hash.Sum(nil))
+	return transfer, nil
+}
+
+// UploadFile attaches a spooled transfer to the parent as a new file. Failed
+// uploads are retried from the spooled copy rather than downloaded again.
+func (p *PipedriveApiClient) UploadFile(ctx context.Context, transfer Transfer, parent model.Parent, filename string, token model.Token) (response.AddFileResponse, error) {
+	var (
+		body response.AddFileResponse
+		err  error
+	)
+
+	if err := parent.Validate(); err != nil {
+		return body, err
+	}
+
+	for attempt := 0; attempt < uploadAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return body, ctx.Err()
+			case <-time.After(uploadBackoff << (attempt - 1)):
+			}
+		}
+
+		var file *os.File
+		file, err = transfer.Open()
+		if err != nil {
+			return body, err
+		}
+
+		body, err = p.postFile(ctx, parent, filename, file, token)
+		file.Close()
+		if err == nil || !isRetryableUpload(err) {
+			return body, err
+		}
+	}
+
+	return body, err
+}
+
+func isRetryableUpload(err error) bool {
+	var serr *UnexpectedStatusCodeError
+	if errors.As(err, &serr) {
+		return serr.Code == http.StatusTooManyRequests || serr.Code >= http.StatusInternalServerError
+	}
+
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,134 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const transferContent = "onlyoffice document content"
+
+func TestDownloadFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// Flushing before writing the body drops the content length header.
+			rw.(http.Flusher).Flush()
+		}
+
+		rw.Write([]byte(transferContent))
+	}))
+	defer server.Close()
+
+	client := NewPipedriveApiClient()
+	sum := sha256.Sum256([]byte(transferContent))
+
+	t.Run("download a file", func(t *testing.T) {
+		transfer, err := client.DownloadFile(context.Background(), server.URL, 1024)
+		assert.NoError(t, err)
+		defer transfer.Close()
+
+		assert.Equal(t, int64(len(transferContent)), transfer.Size)
+		assert.Equal(t, hex.EncodeToString(sum[:]), transfer.Checksum)
+	})
+
+	t.Run("download a file without content length", func(t *testing.T) {
+		transfer, err := client.DownloadFile(context.Background(), server.URL+"/chunked", 1024)
+		assert.NoError(t, err)
+		defer transfer.Close()
+
+		assert.Equal(t, hex.EncodeToString(sum[:]), transfer.Checksum)
+	})
+
+	t.Run("download a file exceeding the limit", func(t *testing.T) {
+		_, err := client.DownloadFile(context.Background(), server.URL, 4)
+		assert.ErrorIs(t, err, ErrInvalidContentLength)
+	})
+
+	t.Run("download a file exceeding the limit without content length", func(t *testing.T) {
+		_, err := client.DownloadFile(context.Background(), server.URL+"/chunked", 4)
+		assert.ErrorIs(t, err, ErrInvalidContentLength)
+	})
+
+	t.Run("remove the spooled file", func(t *testing.T) {
+		transfer, err := client.DownloadFile(context.Background(), server.URL, 1024)
+		assert.NoError(t, err)
+		assert.NoError(t, transfer.Close())
+
+		_, err = os.Stat(transfer.Path)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestUploadFile(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			rw.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		content, _ := io.ReadAll(file)
+		if string(content) != transferContent || r.FormValue("deal_id") != "1" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write([]byte(`{"success":true,"data":{"id":2}}`))
+	}))
+	defer server.Close()
+
+	client := NewPipedriveApiClient()
+	transfer, err := os.CreateTemp("", "pipedrive-transfer-test-*")
+	assert.NoError(t, err)
+	transfer.WriteString(transferContent)
+	transfer.Close()
+
+	spooled := Transfer{Path: transfer.Name(), Size: int64(len(transferContent))}
+	defer spooled.Close()
+
+	t.Run("retry an upload from the spooled file", func(t *testing.T) {
+		res, err := client.UploadFile(context.Background(), spooled, model.Parent{
+			Type: model.ParentDeal,
+			ID:   "1",
+		}, "mock.docx", model.Token{ApiDomain: server.URL})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, res.Data.ID)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	})
+}
@@ -111,6 +111,7 @@ func BuildNewOnlyofficeConfig(path string) func() (*OnlyofficeConfig, error) {
 		var config OnlyofficeConfig
 		config.Onlyoffice.Callback.MaxSize = 20000000
 		config.Onlyoffice.Callback.UploadTimeout = 120
+		config.Onlyoffice.Callback.SaveStrategy = SaveStrategyArchive
 		if path != "" {
 			file, err := os.Open(path)
 			if err != nil {
@@ -145,13 +146,30 @@ func (oc *OnlyofficeBuilderConfig) Validate() error {
 	return nil
 }
 
+// Save strategies define what happens to the previous file once a saved
+// document has been uploaded to pipedrive as a new canonical file.
+const (
+	SaveStrategyKeep    = "keep"
+	SaveStrategyArchive = "archive"
+	SaveStrategyDelete  = "delete"
+)
+
 type OnlyofficeCallbackConfig struct {
-	MaxSize       int64 `yaml:"max_size" env:"ONLYOFFICE_CALLBACK_MAX_SIZE,overwrite"`
-	UploadTimeout int   `yaml:"upload_timeout" env:"ONLYOFFICE_CALLBACK_UPLOAD_TIMEOUT,overwrite"`
+	MaxSize       int64  `yaml:"max_size" env:"ONLYOFFICE_CALLBACK_MAX_SIZE,overwrite"`
+	UploadTimeout int    `yaml:"upload_timeout" env:"ONLYOFFICE_CALLBACK_UPLOAD_TIMEOUT,overwrite"`
+	SaveStrategy  string `yaml:"save_strategy" env:"ONLYOFFICE_CALLBACK_SAVE_STRATEGY,overwrite"`
 }
 
 func (c *OnlyofficeCallbackConfig) Validate() error {
-	return nil
+	switch c.SaveStrategy {
+	case SaveStrategyKeep, SaveStrategyArchive, SaveStrategyDelete:
+		return nil
+	default:
+		return &InvalidConfigurationParameterError{
+			Parameter: "Callback SaveStrategy",
+			Reason:    "Should be one of keep, archive or delete",
+		}
+	}
 }
 
 type OnlyofficeDemoConfig struct {
@@ -185,3 +203,441 @@ func (c *OnlyofficeDemoConfig) Validate() error {
 	}
 	return nil
 }
+
+type RefresherConfig struct {
+	Refresher struct {
+		Enabled  bool `yaml:"enabled" env:"REFRESHER_ENABLED,overwrite"`
+		Interval int  `yaml:"interval" env:"REFRESHER_INTERVAL,overwrite"`
+		Window   int  `yaml:"window" env:"REFRESHER_WINDOW,overwrite"`
+	} `yaml:"refresher"`
+}
+
+func (rc *RefresherConfig) Validate() error {
+	if rc.Refresher.Interval <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Refresher Interval",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	if rc.Refresher.Window <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Refresher Window",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	return nil
+}
+
+func BuildNewRefresherConfig(path string) func() (*RefresherConfig, error) {
+	return func() (*RefresherConfig, error) {
+		var config RefresherConfig
+		config.Refresher.Enabled = true
+		config.Refresher.Interval = 60
+		config.Refresher.Window = 300
+		if path != "" {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+
+			decoder := yaml.NewDecoder(file)
+
+			if err := decoder.Decode(&config); err != nil {
+				return nil, err
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancel()
+		if err := envconfig.Process(ctx, &config); err != nil {
+			return nil, err
+		}
+
+		return &config, config.Validate()
+	}
+}
+
+type TemplatesConfig struct {
+	Templates struct {
+		Path    string `yaml:"path" env:"TEMPLATES_PATH,overwrite"`
+		MaxSize int64  `yaml:"max_size" env:"TEMPLATES_MAX_SIZE,overwrite"`
+	} `yaml:"templates"`
+}
+
+func (tc *TemplatesConfig) Validate() error {
+	if tc.Templates.Path == "" {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Templates Path",
+			Reason:    "Should not be empty",
+		}
+	}
+
+	if tc.Templates.MaxSize <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Templates MaxSize",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	return nil
+}
+
+func BuildNewTemplatesConfig(path string) func() (*TemplatesConfig, error) {
+	return func() (*TemplatesConfig, error) {
+		var config TemplatesConfig
+		config.Templates.Path = "templates"
+		config.Templates.MaxSize = 10000000
+		if path != "" {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+
+			decoder := yaml.NewDecoder(file)
+
+			if err := decoder.Decode(&config); err != nil {
+				return nil, err
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancel()
+		if err := envconfig.Process(ctx, &config); err != nil {
+			return nil, err
+		}
+
+		return &config, config.Validate()
+	}
+}
+
+type SaveQueueConfig struct {
+	Queue struct {
+		Workers     int `yaml:"workers" env:"QUEUE_WORKERS,overwrite"`
+		Interval    int `yaml:"interval" env:"QUEUE_INTERVAL,overwrite"`
+		Lease       int `yaml:"lease" env:"QUEUE_LEASE,overwrite"`
+		MaxAttempts int `yaml:"max_attempts" env:"QUEUE_MAX_ATTEMPTS,overwrite"`
+		BaseDelay   int `yaml:"base_delay" env:"QUEUE_BASE_DELAY,overwrite"`
+		MaxDelay    int `yaml:"max_delay" env:"QUEUE_MAX_DELAY,overwrite"`
+	} `yaml:"queue"`
+}
+
+func (qc *SaveQueueConfig) Validate() error {
+	if qc.Queue.Workers <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Queue Workers",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	if qc.Queue.Interval <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Queue Interval",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	if qc.Queue.Lease <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Queue Lease",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	if qc.Queue.MaxAttempts <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Queue MaxAttempts",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	if qc.Queue.BaseDelay <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Queue BaseDelay",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	if qc.Queue.MaxDelay < qc.Queue.BaseDelay {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Queue MaxDelay",
+			Reason:    "Should not be less than BaseDelay",
+		}
+	}
+
+	return nil
+}
+
+func BuildNewSaveQueueConfig(path string) func() (*SaveQueueConfig, error) {
+	return func() (*SaveQueueConfig, error) {
+		var config SaveQueueConfig
+		config.Queue.Workers = 2
+		config.Queue.Interval = 1
+		config.Queue.Lease = 300
+		config.Queue.MaxAttempts = 8
+		config.Queue.BaseDelay = 5
+		config.Queue.MaxDelay = 600
+		if path != "" {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+
+			decoder := yaml.NewDecoder(file)
+
+			if err := decoder.Decode(&config); err != nil {
+				return nil, err
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancel()
+		if err := envconfig.Process(ctx, &config); err != nil {
+			return nil, err
+		}
+
+		return &config, config.Validate()
+	}
+}
+
+type HealthConfig struct {
+	Health struct {
+		Enabled     bool `yaml:"enabled" env:"HEALTH_ENABLED,overwrite"`
+		Interval    int  `yaml:"interval" env:"HEALTH_INTERVAL,overwrite"`
+		Timeout     int  `yaml:"timeout" env:"HEALTH_TIMEOUT,overwrite"`
+		Concurrency int  `yaml:"concurrency" env:"HEALTH_CONCURRENCY,overwrite"`
+	} `yaml:"health"`
+}
+
+func (hc *HealthConfig) Validate() error {
+	if hc.Health.Interval <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Health Interval",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	if hc.Health.Timeout <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Health Timeout",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	if hc.Health.Concurrency <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Health Concurrency",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	return nil
+}
+
+func BuildNewHealthConfig(path string) func() (*HealthConfig, error) {
+	return func() (*HealthConfig, error) {
+		var config HealthConfig
+		config.Health.Enabled = true
+		config.Health.Interval = 60
+		config.Health.Timeout = 5
+		config.Health.Concurrency = 8
+		if path != "" {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+
+			decoder := yaml.NewDecoder(file)
+
+			if err := decoder.Decode(&config); err != nil {
+				return nil, err
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancel()
+		if err := envconfig.Process(ctx, &config); err != nil {
+			return nil, err
+		}
+
+		return &config, config.Validate()
+	}
+}
+
+type CallbackStateConfig struct {
+	State struct {
+		Secret string `yaml:"secret" env:"CALLBACK_STATE_SECRET,overwrite"`
+		TTL    int    `yaml:"ttl" env:"CALLBACK_STATE_TTL,overwrite"`
+	} `yaml:"state"`
+}
+
+func (sc *CallbackStateConfig) Validate() error {
+	if sc.State.Secret == "" {
+		return &InvalidConfigurationParameterError{
+			Parameter: "State Secret",
+			Reason:    "Should not be empty",
+		}
+	}
+
+	if sc.State.TTL <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "State TTL",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	return nil
+}
+
+func BuildNewCallbackStateConfig(path string) func() (*CallbackStateConfig, error) {
+	return func() (*CallbackStateConfig, error) {
+		var config CallbackStateConfig
+		// States sign every callback of an editing session, including saves posted
+		// after the editor stays open or idle for days, so they outlive the session.
+		config.State.TTL = 2592000
+		if path != "" {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+
+			decoder := yaml.NewDecoder(file)
+
+			if err := decoder.Decode(&config); err != nil {
+				return nil, err
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancel()
+		if err := envconfig.Process(ctx, &config); err != nil {
+			return nil, err
+		}
+
+		return &config, config.Validate()
+	}
+}
+
+type DownloadConfig struct {
+	Download struct {
+		Secret string `yaml:"secret" env:"DOWNLOAD_SECRET,overwrite"`
+		TTL    int    `yaml:"ttl" env:"DOWNLOAD_TTL,overwrite"`
+	} `yaml:"download"`
+}
+
+func (dc *DownloadConfig) Validate() error {
+	if dc.Download.Secret == "" {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Download Secret",
+			Reason:    "Should not be empty",
+		}
+	}
+
+	if dc.Download.TTL <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Download TTL",
+			Reason:    "Should be greater than zero",
+		}
+	}
+
+	return nil
+}
+
+func BuildNewDownloadConfig(path string) func() (*DownloadConfig, error) {
+	return func() (*DownloadConfig, error) {
+		var config DownloadConfig
+		config.Download.TTL = 86400
+		if path != "" {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+
+			decoder := yaml.NewDecoder(file)
+
+			if err := decoder.Decode(&config); err != nil {
+				return nil, err
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancel()
+		if err := envconfig.Process(ctx, &config); err != nil {
+			return nil, err
+		}
+
+		return &config, config.Validate()
+	}
+}
+
+// Distributed cache backends.
+const (
+	DistributedCacheMemory = "memory"
+	DistributedCacheRedis  = "redis"
+)
+
+type DistributedCacheConfig struct {
+	Cache struct {
+		Type     string `yaml:"type" env:"DISTRIBUTED_CACHE_TYPE,overwrite"`
+		Address  string `yaml:"address" env:"DISTRIBUTED_CACHE_ADDRESS,overwrite"`
+		Username string `yaml:"username" env:"DISTRIBUTED_CACHE_USERNAME,overwrite"`
+		Password string `yaml:"password" env:"DISTRIBUTED_CACHE_PASSWORD,overwrite"`
+		Database int    `yaml:"database" env:"DISTRIBUTED_CACHE_DATABASE,overwrite"`
+	} `yaml:"distributed_cache"`
+}
+
+func (dc *DistributedCacheConfig) Validate() error {
+	switch dc.Cache.Type {
+	case DistributedCacheMemory:
+		return nil
+	case DistributedCacheRedis:
+		if dc.Cache.Address == "" {
+			return &InvalidConfigurationParameterError{
+				Parameter: "Distributed Cache Address",
+				Reason:    "Should not be empty for the redis cache",
+			}
+		}
+
+		return nil
+	default:
+		return &InvalidConfigurationParameterError{
+			Parameter: "Distributed Cache Type",
+			Reason:    "Should be either memory or redis",
+		}
+	}
+}
+
+func BuildNewDistributedCacheConfig(path string) func() (*DistributedCacheConfig, error) {
+	return func() (*DistributedCacheConfig, error) {
+		var config DistributedCacheConfig
+		config.Cache.Type = DistributedCacheMemory
+		if path != "" {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+
+			decoder := yaml.NewDecoder(file)
+
+			if err := decoder.Decode(&config); err != nil {
+				return nil, err
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancel()
+		if err := envconfig.Process(ctx, &config); err != nil {
+			return nil, err
+		}
+
+		return &config, config.Validate()
+	}
+}
@@ -0,0 +1,95 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package shared
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidDownloadToken = errors.New("invalid download token")
+
+// pipedriveDomains lists the hosts (and their subdomains) the gateway may fetch files from.
+var pipedriveDomains = []string{"pipedrive.com"}
+
+// IsPipedriveDomain reports whether a company api domain points to pipedrive over https.
+func IsPipedriveDomain(domain string) bool {
+	u, err := url.Parse(strings.TrimSpace(domain))
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range pipedriveDomains {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DownloadToken lets the document server fetch a single pipedrive file through the gateway.
+type DownloadToken struct {
+	ID        string `json:"jti" mapstructure:"jti"`
+	CompanyID int    `json:"cid" mapstructure:"cid"`
+	UserID    int    `json:"uid" mapstructure:"uid"`
+	FileID    string `json:"fid" mapstructure:"fid"`
+	ExpiresAt int64  `json:"exp" mapstructure:"exp"`
+}
+
+func (t DownloadToken) Validate() error {
+	if strings.TrimSpace(t.ID) == "" || t.CompanyID <= 0 || t.UserID <= 0 ||
+		strings.TrimSpace(t.FileID) == "" || t.ExpiresAt <= 0 {
+		return ErrInvalidDownloadToken
+	}
+
+	return nil
+}
+
+func SignDownloadToken(jwtManager crypto.JwtManager, secret string, token DownloadToken, ttl time.Duration) (string, error) {
+	token.ID = uuid.NewString()
+	token.ExpiresAt = time.Now().Add(ttl).Unix()
+	if err := token.Validate(); err != nil {
+		return "", err
+	}
+
+	return jwtManager.Sign(secret, jwt.MapClaims{
+		"jti": token.ID,
+		"cid": token.CompanyID,
+		"uid": token.UserID,
+		"fid": token.FileID,
+		"exp": token.ExpiresAt,
+	})
+}
+
+func VerifyDownloadToken(jwtManager crypto.JwtManager, secret, jwtToken string) (DownloadToken, error) {
+	var token DownloadToken
+	if err := jwtManager.Verify(secret, jwtToken, &token); err != nil {
+		return token, err
+	}
+
+	return token, token.Validate()
+}
@@ -0,0 +1,69 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPipedriveDomain(t *testing.T) {
+	assert.True(t, IsPipedriveDomain("https://company.pipedrive.com"))
+	assert.True(t, IsPipedriveDomain("https://pipedrive.com/"))
+	assert.False(t, IsPipedriveDomain("http://company.pipedrive.com"))
+	assert.False(t, IsPipedriveDomain("https://company.pipedrive.com.evil.io"))
+	assert.False(t, IsPipedriveDomain("https://evilpipedrive.com"))
+	assert.False(t, IsPipedriveDomain("https://company.pipedrive.com:8080"))
+	assert.False(t, IsPipedriveDomain("https://user@company.pipedrive.com"))
+	assert.False(t, IsPipedriveDomain("https://169.254.169.254"))
+}
+
+func TestDownloadToken(t *testing.T) {
+	jwtManager := crypto.NewJwtManager(&config.CryptoConfig{})
+	token := DownloadToken{CompanyID: 1, UserID: 2, FileID: "3"}
+
+	t.Run("verify a signed token", func(t *testing.T) {
+		jwtToken, err := SignDownloadToken(jwtManager, "secret", token, time.Minute)
+		assert.NoError(t, err)
+
+		res, err := VerifyDownloadToken(jwtManager, "secret", jwtToken)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.ID)
+		assert.Equal(t, token.CompanyID, res.CompanyID)
+		assert.Equal(t, token.UserID, res.UserID)
+		assert.Equal(t, token.FileID, res.FileID)
+	})
+
+	t.Run("verify an expired token", func(t *testing.T) {
+		jwtToken, err := SignDownloadToken(jwtManager, "secret", token, -time.Minute)
+		assert.NoError(t, err)
+
+		_, err = VerifyDownloadToken(jwtManager, "secret", jwtToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("sign a token without a file", func(t *testing.T) {
+		_, err := SignDownloadToken(jwtManager, "secret", DownloadToken{CompanyID: 1, UserID: 2}, time.Minute)
+		assert.ErrorIs(t, err, ErrInvalidDownloadToken)
+	})
+}
@@ -0,0 +1,71 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package shared
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/request"
+	"go-micro.dev/v4/broker"
+)
+
+// EventTopic scopes a change event to the deployment namespace.
+func EventTopic(namespace, event string) string {
+	return fmt.Sprintf("%s.%s", namespace, event)
+}
+
+// EventPublisher broadcasts change events over the broker. A failed publish is
+// only logged since cached copies expire on their own.
+type EventPublisher struct {
+	broker messaging.BrokerWithOptions
+	config *config.ServerConfig
+	logger log.Logger
+}
+
+func NewEventPublisher(
+	broker messaging.BrokerWithOptions,
+	config *config.ServerConfig,
+	logger log.Logger,
+) EventPublisher {
+	return EventPublisher{
+		broker: broker,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (p EventPublisher) Publish(event string, ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	if err := p.broker.Broker.Publish(EventTopic(p.config.Namespace, event), &broker.Message{
+		Header: map[string]string{"Content-Type": "application/json"},
+		Body: request.ChangeEvent{
+			IDs:       ids,
+			CreatedAt: time.Now(),
+		}.ToJSON(),
+	}); err != nil {
+		p.logger.Warnf("could not publish %s event: %s", event, err.Error())
+	}
+}
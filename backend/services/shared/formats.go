@@ -72,6 +72,11 @@ func (f Format) IsOpenXMLConvertable() bool {
 	return word || slide || cell
 }
 
+func (f Format) IsConvertableTo(ext string) bool {
+	_, exists := f.Convert[ext]
+	return exists
+}
+
 func (f Format) GetOpenXMLExtension() string {
 	if f.Type == "cell" {
 		return "xlsx"
@@ -0,0 +1,71 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidUserIdentity = errors.New("invalid user identity format")
+
+const identitySeparator = ":"
+
+// UserIdentity is a collision-free key of a pipedrive user within a company.
+type UserIdentity struct {
+	UserID    int
+	CompanyID int
+}
+
+func NewUserIdentity(uid, cid int) UserIdentity {
+	return UserIdentity{
+		UserID:    uid,
+		CompanyID: cid,
+	}
+}
+
+func ParseUserIdentity(id string) (UserIdentity, error) {
+	parts := strings.Split(strings.TrimSpace(id), identitySeparator)
+	if len(parts) != 2 {
+		return UserIdentity{}, ErrInvalidUserIdentity
+	}
+
+	cid, err := strconv.Atoi(parts[0])
+	if err != nil || cid <= 0 {
+		return UserIdentity{}, ErrInvalidUserIdentity
+	}
+
+	uid, err := strconv.Atoi(parts[1])
+	if err != nil || uid <= 0 {
+		return UserIdentity{}, ErrInvalidUserIdentity
+	}
+
+	return NewUserIdentity(uid, cid), nil
+}
+
+func (i UserIdentity) String() string {
+	return fmt.Sprintf("%d%s%d", i.CompanyID, identitySeparator, i.UserID)
+}
+
+// LegacyUserID returns the key users were stored with before identities were introduced.
+func LegacyUserID(uid, cid int) string {
+	return fmt.Sprint(uid + cid)
+}
@@ -0,0 +1,65 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package request
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditConfigBuilt         = "config.built"
+	AuditCallbackSaved       = "callback.saved"
+	AuditSettingsPosted      = "settings.posted"
+	AuditAppUninstalled      = "app.uninstalled"
+	AuditFileConverted       = "file.converted"
+	AuditFileExported        = "file.exported"
+	AuditSessionForceSaved   = "session.forcesaved"
+	AuditSessionDisconnected = "session.disconnected"
+)
+
+type AuditEvent struct {
+	Type      string            `json:"type"`
+	CompanyID string            `json:"company_id"`
+	UserID    string            `json:"user_id"`
+	DealID    string            `json:"deal_id"`
+	FileID    string            `json:"file_id"`
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func (e AuditEvent) ToJSON() []byte {
+	buf, _ := json.Marshal(e)
+	return buf
+}
+
+type AuditQuery struct {
+	CompanyID string    `json:"company_id"`
+	UserID    string    `json:"user_id"`
+	DealID    string    `json:"deal_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Page      int       `json:"page"`
+	Limit     int       `json:"limit"`
+}
+
+func (q AuditQuery) ToJSON() []byte {
+	buf, _ := json.Marshal(q)
+	return buf
+}
@@ -34,16 +34,30 @@ func (e *MissingRequestFieldsError) Error() string {
 	return fmt.Sprintf("missing %s's field %s. Reason: %s", e.Request, e.Field, e.Reason)
 }
 
+const (
+	CallbackActionDisconnected = 0
+	CallbackActionConnected    = 1
+	CallbackActionForceSave    = 2
+)
+
+type CallbackAction struct {
+	Type   int    `json:"type"`
+	UserID string `json:"userid"`
+}
+
 type CallbackRequest struct {
-	Actions []struct {
-		Type   int    `json:"type"`
-		UserID string `json:"userid"`
-	} `json:"actions"`
-	Key    string   `json:"key"`
-	Status int      `json:"status"`
-	Users  []string `json:"users"`
-	URL    string   `json:"url"`
-	Token  string   `json:"token"`
+	Actions       []CallbackAction `json:"actions"`
+	Key           string           `json:"key"`
+	Status        int              `json:"status"`
+	Users         []string         `json:"users"`
+	URL           string           `json:"url"`
+	ForceSaveType int              `json:"forcesavetype"`
+	Token         string           `json:"token"`
+}
+
+// CallbackHeaderClaims is the token the document server sends in its JWT header.
+type CallbackHeaderClaims struct {
+	Payload CallbackRequest `json:"payload" mapstructure:"payload"`
 }
 
 func (cr CallbackRequest) ToJSON() []byte {
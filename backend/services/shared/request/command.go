@@ -24,21 +24,31 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-type BaseCommandRequest struct {
-	jwt.RegisteredClaims
-	C string `json:"c"`
+type TokenCommandRequest struct {
+	Token string `json:"token"`
 }
 
-func (c BaseCommandRequest) ToJSON() []byte {
+func (c TokenCommandRequest) ToJSON() []byte {
 	buf, _ := json.Marshal(c)
 	return buf
 }
 
-type TokenCommandRequest struct {
-	Token string `json:"token"`
+type CommandMeta struct {
+	Title string `json:"title"`
 }
 
-func (c TokenCommandRequest) ToJSON() []byte {
+// CommandRequest is a document command service request. Key, users and meta
+// are only sent with the commands that accept them.
+type CommandRequest struct {
+	jwt.RegisteredClaims
+	C        string       `json:"c"`
+	Key      string       `json:"key,omitempty"`
+	Users    []string     `json:"users,omitempty"`
+	UserData string       `json:"userdata,omitempty"`
+	Meta     *CommandMeta `json:"meta,omitempty"`
+}
+
+func (c CommandRequest) ToJSON() []byte {
 	buf, _ := json.Marshal(c)
 	return buf
 }
@@ -18,17 +18,68 @@
 
 package request
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
+)
 
 type BuildConfigRequest struct {
-	UID       int    `json:"uid"`
-	CID       int    `json:"cid"`
-	Deal      string `json:"deal_id"`
-	UserAgent string `json:"user_agent"`
-	FileID    string `json:"file_id"`
-	Filename  string `json:"file_name"`
-	DocKey    string `json:"doc_key"`
-	Dark      bool   `json:"dark"`
+	UID       int          `json:"uid"`
+	CID       int          `json:"cid"`
+	Parent    model.Parent `json:"parent"`
+	UserAgent string       `json:"user_agent"`
+	FileID    string       `json:"file_id"`
+	Filename  string       `json:"file_name"`
+	Dark      bool         `json:"dark"`
+}
+
+// legacyParent is the deal id services released before parents were introduced
+// send and expect instead of a parent. It is kept on the wire for one release
+// so that services of both versions understand each other during a deploy.
+type legacyParent struct {
+	Deal string `json:"deal_id,omitempty"`
+}
+
+func newLegacyParent(parent model.Parent) legacyParent {
+	return legacyParent{Deal: parent.DealID()}
+}
+
+func (l legacyParent) apply(parent *model.Parent) error {
+	if parent.String() != "" || l.Deal == "" {
+		return nil
+	}
+
+	deal, err := model.ParseParent(l.Deal)
+	if err != nil {
+		return err
+	}
+
+	*parent = deal
+	return nil
+}
+
+func (c BuildConfigRequest) MarshalJSON() ([]byte, error) {
+	type plain BuildConfigRequest
+	return json.Marshal(struct {
+		plain
+		legacyParent
+	}{plain(c), newLegacyParent(c.Parent)})
+}
+
+func (c *BuildConfigRequest) UnmarshalJSON(buf []byte) error {
+	type plain BuildConfigRequest
+	var req struct {
+		plain
+		legacyParent
+	}
+
+	if err := json.Unmarshal(buf, &req); err != nil {
+		return err
+	}
+
+	*c = BuildConfigRequest(req.plain)
+	return req.apply(&c.Parent)
 }
 
 func (c BuildConfigRequest) ToJSON() []byte {
@@ -0,0 +1,66 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package request
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLegacyParent(t *testing.T) {
+	t.Run("decode a legacy deal id", func(t *testing.T) {
+		var req BuildConfigRequest
+		assert.NoError(t, json.Unmarshal([]byte(`{"uid":1,"cid":2,"deal_id":"7","file_id":"3"}`), &req))
+		assert.Equal(t, model.NewParent(model.ParentDeal, "7"), req.Parent)
+		assert.Equal(t, "3", req.FileID)
+	})
+
+	t.Run("prefer the parent over a legacy deal id", func(t *testing.T) {
+		var req ConvertFileRequest
+		assert.NoError(t, json.Unmarshal([]byte(`{"parent":"person:4","deal_id":"7"}`), &req))
+		assert.Equal(t, model.NewParent(model.ParentPerson, "4"), req.Parent)
+	})
+
+	t.Run("keep sending deal ids", func(t *testing.T) {
+		var legacy struct {
+			Deal    string `json:"deal_id"`
+			Replace bool   `json:"replace"`
+		}
+
+		req := ExportFileRequest{
+			ConvertFileRequest: ConvertFileRequest{Parent: model.NewParent(model.ParentDeal, "7")},
+			Replace:            true,
+		}
+		assert.NoError(t, json.Unmarshal(req.ToJSON(), &legacy))
+		assert.Equal(t, "7", legacy.Deal)
+		assert.True(t, legacy.Replace)
+
+		var decoded ExportFileRequest
+		assert.NoError(t, json.Unmarshal(req.ToJSON(), &decoded))
+		assert.Equal(t, req, decoded)
+	})
+
+	t.Run("omit deal ids of other parents", func(t *testing.T) {
+		req := BuildConfigRequest{Parent: model.NewParent(model.ParentLead, "abc-1")}
+		assert.NotContains(t, string(req.ToJSON()), "deal_id")
+	})
+}
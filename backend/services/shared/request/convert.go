@@ -0,0 +1,115 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package request
+
+import (
+	"encoding/json"
+
+	"github.com/ONLYOFFICE/onlyoffice-pipedrive/services/shared/client/model"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type ConvertRequest struct {
+	jwt.RegisteredClaims
+	Async      bool   `json:"async"`
+	FileType   string `json:"filetype"`
+	Key        string `json:"key"`
+	OutputType string `json:"outputtype"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Token      string `json:"token,omitempty"`
+}
+
+func (c ConvertRequest) ToJSON() []byte {
+	buf, _ := json.Marshal(c)
+	return buf
+}
+
+type ConvertFileRequest struct {
+	UID      int          `json:"uid"`
+	CID      int          `json:"cid"`
+	Parent   model.Parent `json:"parent"`
+	FileID   string       `json:"file_id"`
+	Filename string       `json:"file_name"`
+}
+
+func (c ConvertFileRequest) MarshalJSON() ([]byte, error) {
+	type plain ConvertFileRequest
+	return json.Marshal(struct {
+		plain
+		legacyParent
+	}{plain(c), newLegacyParent(c.Parent)})
+}
+
+func (c *ConvertFileRequest) UnmarshalJSON(buf []byte) error {
+	type plain ConvertFileRequest
+	var req struct {
+		plain
+		legacyParent
+	}
+
+	if err := json.Unmarshal(buf, &req); err != nil {
+		return err
+	}
+
+	*c = ConvertFileRequest(req.plain)
+	return req.apply(&c.Parent)
+}
+
+func (c ConvertFileRequest) ToJSON() []byte {
+	buf, _ := json.Marshal(c)
+	return buf
+}
+
+type ExportFileRequest struct {
+	ConvertFileRequest
+	Replace bool `json:"replace"`
+}
+
+// ExportFileRequest spells its fields out, since the json methods promoted from
+// ConvertFileRequest would otherwise drop Replace.
+func (c ExportFileRequest) MarshalJSON() ([]byte, error) {
+	type plain ConvertFileRequest
+	return json.Marshal(struct {
+		plain
+		legacyParent
+		Replace bool `json:"replace"`
+	}{plain(c.ConvertFileRequest), newLegacyParent(c.Parent), c.Replace})
+}
+
+func (c *ExportFileRequest) UnmarshalJSON(buf []byte) error {
+	type plain ConvertFileRequest
+	var req struct {
+		plain
+		legacyParent
+		Replace bool `json:"replace"`
+	}
+
+	if err := json.Unmarshal(buf, &req); err != nil {
+		return err
+	}
+
+	*c = ExportFileRequest{ConvertFileRequest: ConvertFileRequest(req.plain), Replace: req.Replace}
+	return req.apply(&c.Parent)
+}
+
+func (c ExportFileRequest) ToJSON() []byte {
+	buf, _ := json.Marshal(c)
+	return buf
+}
@@ -27,4 +27,5 @@ var (
 	ErrInvalidDocHeader  = errors.New("invalid doc server header")
 	ErrInvalidDemoPeriod = errors.New("demo period has expired")
 	ErrHttpNotAllowed    = errors.New("document server must use https protocol for pipedrive integration")
+	ErrInvalidDocMode    = errors.New("invalid editor mode")
 )
@@ -0,0 +1,54 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package request
+
+import "encoding/json"
+
+type RevisionRequest struct {
+	Key          string   `json:"key"`
+	FileID       string   `json:"file_id"`
+	PreviousID   string   `json:"previous_id"`
+	CompanyID    string   `json:"company_id"`
+	DealID       string   `json:"deal_id"`
+	UserID       string   `json:"user_id"`
+	UserName     string   `json:"user_name"`
+	Size         int64    `json:"size"`
+	Checksum     string   `json:"checksum"`
+	Forcesave    bool     `json:"forcesave"`
+	Contributors []string `json:"contributors"`
+}
+
+func (r RevisionRequest) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
+
+type BuildHistoryRequest struct {
+	UID      int    `json:"uid"`
+	CID      int    `json:"cid"`
+	FileID   string `json:"file_id"`
+	Filename string `json:"file_name"`
+	DocKey   string `json:"doc_key"`
+	Version  int    `json:"version"`
+}
+
+func (r BuildHistoryRequest) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
@@ -24,12 +24,51 @@ import (
 	"strings"
 )
 
+type DocPermissions struct {
+	Print                   bool `json:"print" mapstructure:"print"`
+	DisableDownload         bool `json:"disable_download" mapstructure:"disable_download"`
+	ForceReview             bool `json:"force_review" mapstructure:"force_review"`
+	EditCommentAuthorOnly   bool `json:"edit_comment_author_only" mapstructure:"edit_comment_author_only"`
+	DeleteCommentAuthorOnly bool `json:"delete_comment_author_only" mapstructure:"delete_comment_author_only"`
+}
+
+type DocRoles struct {
+	Admin          string            `json:"admin" mapstructure:"admin"`
+	Regular        string            `json:"regular" mapstructure:"regular"`
+	PermissionSets map[string]string `json:"permission_sets" mapstructure:"permission_sets"`
+}
+
+func isValidDocMode(mode string) bool {
+	switch strings.TrimSpace(mode) {
+	case "", "edit", "review", "comment", "view":
+		return true
+	default:
+		return false
+	}
+}
+
+func (r DocRoles) Validate() error {
+	if !isValidDocMode(r.Admin) || !isValidDocMode(r.Regular) {
+		return ErrInvalidDocMode
+	}
+
+	for _, mode := range r.PermissionSets {
+		if !isValidDocMode(mode) {
+			return ErrInvalidDocMode
+		}
+	}
+
+	return nil
+}
+
 type DocSettings struct {
-	CompanyID   int    `json:"company_id" mapstructure:"company_id"`
-	DocAddress  string `json:"doc_address" mapstructure:"doc_address"`
-	DocSecret   string `json:"doc_secret" mapstructure:"doc_secret"`
-	DocHeader   string `json:"doc_header" mapstructure:"doc_header"`
-	DemoEnabled bool   `json:"demo_enabled" mapstructure:"demo_enabled"`
+	CompanyID   int             `json:"company_id" mapstructure:"company_id"`
+	DocAddress  string          `json:"doc_address" mapstructure:"doc_address"`
+	DocSecret   string          `json:"doc_secret" mapstructure:"doc_secret"`
+	DocHeader   string          `json:"doc_header" mapstructure:"doc_header"`
+	DemoEnabled bool            `json:"demo_enabled" mapstructure:"demo_enabled"`
+	Permissions *DocPermissions `json:"permissions,omitempty" mapstructure:"permissions"`
+	Roles       *DocRoles       `json:"roles,omitempty" mapstructure:"roles"`
 }
 
 func (c DocSettings) ToJSON() []byte {
@@ -46,6 +85,12 @@ func (c DocSettings) Validate() error {
 		return ErrInvalidCompanyID
 	}
 
+	if c.Roles != nil {
+		if err := c.Roles.Validate(); err != nil {
+			return err
+		}
+	}
+
 	hasCredentials := c.DocAddress != "" || c.DocSecret != "" || c.DocHeader != ""
 	if hasCredentials {
 		if c.DocAddress == "" {
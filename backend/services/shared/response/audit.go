@@ -0,0 +1,46 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEventResponse struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	UserID    string            `json:"user_id"`
+	DealID    string            `json:"deal_id"`
+	FileID    string            `json:"file_id"`
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type AuditEventsResponse struct {
+	Events []AuditEventResponse `json:"events"`
+	Total  int64                `json:"total"`
+	Page   int                  `json:"page"`
+	Limit  int                  `json:"limit"`
+}
+
+func (r AuditEventsResponse) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
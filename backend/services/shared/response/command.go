@@ -21,3 +21,67 @@ package response
 type BaseCommandResponse struct {
 	Error int `json:"error"`
 }
+
+func (r BaseCommandResponse) Code() int {
+	return r.Error
+}
+
+type VersionCommandResponse struct {
+	BaseCommandResponse
+	Version string `json:"version"`
+}
+
+type LicenseInfo struct {
+	EndDate         string `json:"end_date"`
+	Trial           bool   `json:"trial"`
+	Customization   bool   `json:"customization"`
+	Branding        bool   `json:"branding"`
+	Light           bool   `json:"light"`
+	Connections     int    `json:"connections"`
+	ConnectionsView int    `json:"connections_view"`
+	UsersCount      int    `json:"users_count"`
+	UsersViewCount  int    `json:"users_view_count"`
+	UsersExpire     int    `json:"users_expire"`
+}
+
+type LicenseServerInfo struct {
+	ResultType   int    `json:"resultType"`
+	PackageType  int    `json:"packageType"`
+	BuildDate    string `json:"buildDate"`
+	BuildVersion string `json:"buildVersion"`
+	BuildNumber  int    `json:"buildNumber"`
+}
+
+type LicenseUsersCount struct {
+	Unique    int `json:"unique"`
+	Anonymous int `json:"anonymous"`
+}
+
+type LicenseQuotaUsage struct {
+	ConnectionsCount int               `json:"connectionsCount"`
+	UsersCount       LicenseUsersCount `json:"usersCount"`
+}
+
+type LicenseQuota struct {
+	Edit LicenseQuotaUsage `json:"edit"`
+	View LicenseQuotaUsage `json:"view"`
+}
+
+type LicenseCommandResponse struct {
+	BaseCommandResponse
+	License LicenseInfo       `json:"license"`
+	Server  LicenseServerInfo `json:"server"`
+	Quota   LicenseQuota      `json:"quota"`
+}
+
+type InfoCommandResponse struct {
+	BaseCommandResponse
+	Key   string   `json:"key"`
+	Users []string `json:"users"`
+}
+
+// KeyCommandResponse is returned by the drop, forcesave and meta commands.
+type KeyCommandResponse struct {
+	BaseCommandResponse
+	Key string `json:"key"`
+}
@@ -0,0 +1,45 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package response
+
+import "encoding/json"
+
+type ConvertResponse struct {
+	EndConvert bool   `json:"endConvert"`
+	FileURL    string `json:"fileUrl"`
+	FileType   string `json:"fileType"`
+	Percent    int    `json:"percent"`
+	Error      int    `json:"error"`
+}
+
+func (r ConvertResponse) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
+
+type ConvertFileResponse struct {
+	FileID   string `json:"file_id"`
+	Filename string `json:"file_name"`
+	Replaced bool   `json:"replaced,omitempty"`
+}
+
+func (r ConvertFileResponse) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
@@ -0,0 +1,68 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package response
+
+import (
+	"encoding/json"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type BuildHistoryResponse struct {
+	CurrentVersion int            `json:"currentVersion"`
+	History        []HistoryEntry `json:"history"`
+}
+
+func (r BuildHistoryResponse) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
+
+type HistoryEntry struct {
+	Created string `json:"created,omitempty"`
+	Key     string `json:"key"`
+	User    *User  `json:"user,omitempty"`
+	Version int    `json:"version"`
+}
+
+type BuildHistoryDataResponse struct {
+	jwt.RegisteredClaims
+	FileType string `json:"fileType"`
+	Key      string `json:"key"`
+	URL      string `json:"url"`
+	Version  int    `json:"version"`
+	Token    string `json:"token,omitempty"`
+}
+
+func (r BuildHistoryDataResponse) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
+
+type RevisionResponse struct {
+	DocumentID string `json:"document_id"`
+	FileID     string `json:"file_id"`
+	PreviousID string `json:"previous_id"`
+	Version    int    `json:"version"`
+}
+
+func (r RevisionResponse) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
@@ -23,12 +23,28 @@ import (
 	"time"
 )
 
+type DocPermissionsResponse struct {
+	Print                   bool `json:"print"`
+	DisableDownload         bool `json:"disable_download"`
+	ForceReview             bool `json:"force_review"`
+	EditCommentAuthorOnly   bool `json:"edit_comment_author_only"`
+	DeleteCommentAuthorOnly bool `json:"delete_comment_author_only"`
+}
+
+type DocRolesResponse struct {
+	Admin          string            `json:"admin"`
+	Regular        string            `json:"regular"`
+	PermissionSets map[string]string `json:"permission_sets"`
+}
+
 type DocSettingsResponse struct {
-	DocAddress  string    `json:"doc_address"`
-	DocSecret   string    `json:"doc_secret"`
-	DocHeader   string    `json:"doc_header"`
-	DemoEnabled bool      `json:"demo_enabled"`
-	DemoStarted time.Time `json:"demo_started"`
+	DocAddress  string                 `json:"doc_address"`
+	DocSecret   string                 `json:"doc_secret"`
+	DocHeader   string                 `json:"doc_header"`
+	DemoEnabled bool                   `json:"demo_enabled"`
+	DemoStarted time.Time              `json:"demo_started"`
+	Permissions DocPermissionsResponse `json:"permissions"`
+	Roles       DocRolesResponse       `json:"roles"`
 }
 
 func (r DocSettingsResponse) ToJSON() []byte {
@@ -44,3 +60,19 @@ func (r SettingsConfiguredResponse) ToJSON() []byte {
 	buf, _ := json.Marshal(r)
 	return buf
 }
+
+// DocServerStatusResponse is the latest health check result of a company's document server.
+type DocServerStatusResponse struct {
+	Configured bool      `json:"configured"`
+	Demo       bool      `json:"demo"`
+	Reachable  bool      `json:"reachable"`
+	Version    string    `json:"version,omitempty"`
+	Latency    int64     `json:"latency"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+func (r DocServerStatusResponse) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
@@ -22,6 +22,7 @@ import "encoding/json"
 
 type UserResponse struct {
 	ID           string `json:"id" mapstructure:"id"`
+	CompanyID    string `json:"company_id" mapstructure:"company_id"`
 	AccessToken  string `json:"access_token" mapstructure:"access_token"`
 	RefreshToken string `json:"refresh_token" mapstructure:"refresh_token"`
 	TokenType    string `json:"token_type" mapstructure:"token_type"`
@@ -45,3 +46,24 @@ func (ut UserTokenResponse) ToJSON() []byte {
 	buf, _ := json.Marshal(ut)
 	return buf
 }
+
+type CompanyUsersResponse struct {
+	IDs []string `json:"ids"`
+}
+
+func (r CompanyUsersResponse) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
+
+type CompanyPurgeResponse struct {
+	CompanyID string   `json:"company_id"`
+	Purged    bool     `json:"purged"`
+	Users     []string `json:"users"`
+	Settings  bool     `json:"settings"`
+}
+
+func (r CompanyPurgeResponse) ToJSON() []byte {
+	buf, _ := json.Marshal(r)
+	return buf
+}
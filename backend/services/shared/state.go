@@ -0,0 +1,81 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package shared
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidCallbackState = errors.New("invalid callback state")
+
+// CallbackState is what the callback service needs to know about an editing
+// session. The builder signs it into the callback url so that the document
+// server cannot choose the company or the upload target.
+type CallbackState struct {
+	CompanyID int    `json:"cid" mapstructure:"cid"`
+	Parent    string `json:"did" mapstructure:"did"`
+	FileID    string `json:"fid" mapstructure:"fid"`
+	Filename  string `json:"filename" mapstructure:"filename"`
+	Key       string `json:"key" mapstructure:"key"`
+	ExpiresAt int64  `json:"exp" mapstructure:"exp"`
+}
+
+func (s CallbackState) Validate() error {
+	if s.CompanyID <= 0 || strings.TrimSpace(s.Parent) == "" ||
+		strings.TrimSpace(s.FileID) == "" || strings.TrimSpace(s.Key) == "" {
+		return ErrInvalidCallbackState
+	}
+
+	// Tokens without an expiration would be accepted forever.
+	if s.ExpiresAt <= 0 {
+		return ErrInvalidCallbackState
+	}
+
+	return nil
+}
+
+func SignCallbackState(jwtManager crypto.JwtManager, secret string, state CallbackState, ttl time.Duration) (string, error) {
+	state.ExpiresAt = time.Now().Add(ttl).Unix()
+	if err := state.Validate(); err != nil {
+		return "", err
+	}
+
+	return jwtManager.Sign(secret, jwt.MapClaims{
+		"cid":      state.CompanyID,
+		"did":      state.Parent,
+		"fid":      state.FileID,
+		"filename": state.Filename,
+		"key":      state.Key,
+		"exp":      state.ExpiresAt,
+	})
+}
+
+func VerifyCallbackState(jwtManager crypto.JwtManager, secret, token string) (CallbackState, error) {
+	var state CallbackState
+	if err := jwtManager.Verify(secret, token, &state); err != nil {
+		return state, err
+	}
+
+	return state, state.Validate()
+}
@@ -0,0 +1,87 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallbackState(t *testing.T) {
+	jwtManager := crypto.NewJwtManager(&config.CryptoConfig{})
+	state := CallbackState{
+		CompanyID: 1,
+		Parent:    "deal:2",
+		FileID:    "3",
+		Filename:  "mock.docx",
+		Key:       "key",
+	}
+
+	t.Run("verify a signed state", func(t *testing.T) {
+		token, err := SignCallbackState(jwtManager, "secret", state, time.Minute)
+		assert.NoError(t, err)
+
+		res, err := VerifyCallbackState(jwtManager, "secret", token)
+		assert.NoError(t, err)
+		assert.Equal(t, state.CompanyID, res.CompanyID)
+		assert.Equal(t, state.Parent, res.Parent)
+		assert.Equal(t, state.FileID, res.FileID)
+		assert.Equal(t, state.Filename, res.Filename)
+		assert.Equal(t, state.Key, res.Key)
+	})
+
+	t.Run("verify a state signed with another secret", func(t *testing.T) {
+		token, err := SignCallbackState(jwtManager, "another", state, time.Minute)
+		assert.NoError(t, err)
+
+		_, err = VerifyCallbackState(jwtManager, "secret", token)
+		assert.Error(t, err)
+	})
+
+	t.Run("verify an expired state", func(t *testing.T) {
+		token, err := SignCallbackState(jwtManager, "secret", state, -time.Minute)
+		assert.NoError(t, err)
+
+		_, err = VerifyCallbackState(jwtManager, "secret", token)
+		assert.Error(t, err)
+	})
+
+	t.Run("verify a state without an expiration", func(t *testing.T) {
+		token, err := jwtManager.Sign("secret", jwt.MapClaims{
+			"cid": state.CompanyID,
+			"did": state.Parent,
+			"fid": state.FileID,
+			"key": state.Key,
+		})
+		assert.NoError(t, err)
+
+		_, err = VerifyCallbackState(jwtManager, "secret", token)
+		assert.ErrorIs(t, err, ErrInvalidCallbackState)
+	})
+
+	t.Run("sign an incomplete state", func(t *testing.T) {
+		_, err := SignCallbackState(jwtManager, "secret", CallbackState{CompanyID: 1}, time.Minute)
+		assert.ErrorIs(t, err, ErrInvalidCallbackState)
+	})
+}
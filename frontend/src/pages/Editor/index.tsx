@@ -17,6 +17,7 @@
  */
 
 import React from "react";
+import axios from "axios";
 import { useSearchParams } from "react-router-dom";
 import { useTranslation } from "react-i18next";
 import { DocumentEditor } from "@onlyoffice/document-editor-react";
@@ -28,10 +29,28 @@ import { OnlyofficeSpinner } from "@components/spinner";
 
 import { useBuildConfig } from "@hooks/useBuildConfig";
 
+import { fetchHistory, fetchHistoryData } from "@services/history";
+
 import { getFileFavicon } from "@utils/file";
 
 import Icon from "@assets/nofile.svg";
 
+type HistoryEditor = {
+  refreshHistory?: (history: object) => void;
+  setHistoryData?: (data: object) => void;
+};
+
+const getEditor = () =>
+  (
+    window as {
+      DocEditor?: {
+        instances?: {
+          docxEditor?: HistoryEditor;
+        };
+      };
+    }
+  ).DocEditor?.instances?.docxEditor;
+
 const onEditor = () => {
   const loader = document.getElementById("eloader");
   if (loader) {
@@ -61,6 +80,54 @@ export const OnlyofficeEditorPage: React.FC = () => {
   );
 
   const validConfig = !error && !isLoading && data;
+
+  const onRequestHistory = async () => {
+    const editor = getEditor();
+    if (!data || !editor?.refreshHistory) return;
+    try {
+      const history = await fetchHistory(
+        params.get("token") || "",
+        params.get("id") || "",
+        data.document.key,
+      );
+      editor.refreshHistory(history);
+    } catch {
+      editor.refreshHistory({
+        error: t(
+          "editor.history.error",
+          "Could not load the version history",
+        ),
+      });
+    }
+  };
+
+  const onRequestHistoryData = async (event: object) => {
+    const editor = getEditor();
+    const version = (event as { data: number }).data;
+    if (!data || !editor?.setHistoryData) return;
+    try {
+      const history = await fetchHistoryData(
+        params.get("token") || "",
+        params.get("id") || "",
+        params.get("name") || "new.docx",
+        data.document.key,
+        version,
+      );
+      editor.setHistoryData(history);
+    } catch (err) {
+      const removed = axios.isAxiosError(err) && err.response?.status === 410;
+      editor.setHistoryData({
+        error: removed
+          ? t(
+              "editor.history.removed",
+              "This version has been removed from Pipedrive",
+            )
+          : t("editor.history.error", "Could not load the version history"),
+        version,
+      });
+    }
+  };
+
   const backgroundClass = isDark ? "bg-dark-bg" : "bg-white";
 
   const onDocumentReady = () => {
@@ -173,6 +240,11 @@ export const OnlyofficeEditorPage: React.FC = () => {
                 },
                 onWarning: onEditor,
                 onDocumentReady,
+                onRequestHistory,
+                onRequestHistoryData,
+                onRequestHistoryClose: () => {
+                  window.location.reload();
+                },
               },
             }}
           />
@@ -33,7 +33,7 @@ import { OnlyofficeBackgroundError } from "@layouts/ErrorBackground";
 
 import { useFileSearch } from "@hooks/useFileSearch";
 
-import { checkSettings } from "@services/settings";
+import { checkSettings, getSettingsStatus } from "@services/settings";
 
 import { formatBytes, getFileIcon, isFileSupported } from "@utils/file";
 import { getCurrentURL } from "@utils/url";
@@ -48,6 +48,7 @@ export const Main: React.FC = () => {
   const [settingsConfigured, setSettingsConfigured] = useState<boolean | null>(
     null,
   );
+  const [serverReachable, setServerReachable] = useState(true);
   const { isLoading, fetchNextPage, isFetchingNextPage, files, hasNextPage } =
     useFileSearch(
       `${url}api/v1/deals/${parameters.get("selectedIds")}/files`,
@@ -76,6 +77,10 @@ export const Main: React.FC = () => {
         setSDK(s);
         const configured = await checkSettings(s);
         setSettingsConfigured(configured);
+        if (configured) {
+          const status = await getSettingsStatus(s);
+          setServerReachable(!status?.configured || status.reachable);
+        }
       })
       .catch(() => {
         setSDK(null);
@@ -108,6 +113,26 @@ export const Main: React.FC = () => {
     );
   }
 
+  if (!serverReachable) {
+    return (
+      <OnlyofficeBackgroundError
+        Icon={<SettingsError className="mb-5" />}
+        title={t(
+          "background.unavailable.title",
+          "Document Server is unavailable",
+        )}
+        subtitle={t(
+          "background.unavailable.subtitle",
+          "Your Document Server does not respond. Please check that it is running or review the settings.",
+        )}
+        button={t("background.settings.button", "Go to Settings")}
+        onClick={async () => {
+          window.open(`${url}settings/marketplace`, "_blank");
+        }}
+      />
+    );
+  }
+
   return (
     <div className="table-shadow h-full bg-white dark:bg-dark-bg">
       <div className="overflow-x-hidden overflow-y-auto custom-scroll px-5 h-[85%] md:justify-between mr-5">
@@ -33,10 +33,30 @@ import { getPipedriveMe } from "@services/me";
 
 import { AuthToken } from "@context/TokenContext";
 
+import {
+  EditorMode,
+  PermissionsPolicy,
+  RolesPolicy,
+} from "src/types/settings";
+
 import OnlyofficeLogo from "@assets/onlyoffice-logo.svg";
 import SettingsError from "@assets/settings-error.svg";
 import { getCurrentURL } from "@utils/url";
 
+const defaultPermissions: PermissionsPolicy = {
+  print: false,
+  disable_download: false,
+  force_review: false,
+  edit_comment_author_only: false,
+  delete_comment_author_only: false,
+};
+
+const defaultRoles: RolesPolicy = {
+  admin: "edit",
+  regular: "edit",
+  permission_sets: {},
+};
+
 function SettingsErrorIcon() {
   return (
     <div className="flex flex-col items-center justify-center">
@@ -57,8 +77,55 @@ export const SettingsPage: React.FC = () => {
   const [header, setHeader] = useState<string | undefined>(undefined);
   const [demoEnabled, setDemoEnabled] = useState(false);
   const [demoStarted, setDemoStarted] = useState<string | undefined>(undefined);
+  const [permissions, setPermissions] =
+    useState<PermissionsPolicy>(defaultPermissions);
+  const [roles, setRoles] = useState<RolesPolicy>(defaultRoles);
   const [saving, setSaving] = useState(false);
 
+  const modeOptions: { value: EditorMode; label: string }[] = [
+    { value: "edit", label: t("settings.roles.mode.edit", "Edit") },
+    { value: "review", label: t("settings.roles.mode.review", "Review") },
+    { value: "comment", label: t("settings.roles.mode.comment", "Comment") },
+    { value: "view", label: t("settings.roles.mode.view", "View") },
+  ];
+
+  const roleOptions: { name: "admin" | "regular"; label: string }[] = [
+    { name: "admin", label: t("settings.roles.admin", "Admins") },
+    { name: "regular", label: t("settings.roles.regular", "Regular users") },
+  ];
+
+  const permissionOptions: {
+    name: keyof PermissionsPolicy;
+    label: string;
+  }[] = [
+    {
+      name: "print",
+      label: t("settings.permissions.print", "Allow printing"),
+    },
+    {
+      name: "disable_download",
+      label: t("settings.permissions.download", "Disable downloading"),
+    },
+    {
+      name: "force_review",
+      label: t("settings.permissions.review", "Force review mode"),
+    },
+    {
+      name: "edit_comment_author_only",
+      label: t(
+        "settings.permissions.comments.edit",
+        "Only authors can edit their comments",
+      ),
+    },
+    {
+      name: "delete_comment_author_only",
+      label: t(
+        "settings.permissions.comments.delete",
+        "Only authors can delete their comments",
+      ),
+    },
+  ];
+
   const isDemoValid = (): boolean => {
     if (!demoEnabled) return false;
 
@@ -154,6 +221,12 @@ export const SettingsPage: React.FC = () => {
               setHeader(res.doc_header);
               setDemoEnabled(res.demo_enabled);
               setDemoStarted(res.demo_started);
+              setPermissions({ ...defaultPermissions, ...res.permissions });
+              setRoles({
+                admin: res.roles?.admin || defaultRoles.admin,
+                regular: res.roles?.regular || defaultRoles.regular,
+                permission_sets: res.roles?.permission_sets || {},
+              });
               setAdmin(true);
             }
           } catch {
@@ -205,6 +278,8 @@ export const SettingsPage: React.FC = () => {
           secret || "",
           header || "",
           demoEnabled,
+          permissions,
+          roles,
         );
         setDemoStarted(demoStarted || new Date().toISOString());
         await sdk.execute(Command.SHOW_SNACKBAR, {
@@ -399,6 +474,70 @@ export const SettingsPage: React.FC = () => {
                     )}
               </p>
             </div>
+            <div className="pl-5 pr-5 mt-4">
+              <p className="text-sm font-semibold text-gray-900 dark:text-dark-text mb-2">
+                {t("settings.permissions.title", "Editor permissions")}
+              </p>
+              {permissionOptions.map((option) => (
+                <div key={option.name} className="flex items-center mb-2">
+                  <input
+                    type="checkbox"
+                    id={`permissions-${option.name}`}
+                    checked={permissions[option.name]}
+                    onChange={(e) =>
+                      setPermissions({
+                        ...permissions,
+                        [option.name]: e.target.checked,
+                      })
+                    }
+                    disabled={saving}
+                    className="w-4 h-4 text-blue-600 bg-gray-100 dark:bg-dark-bg border-gray-300 dark:border-dark-border rounded focus:ring-blue-500 focus:ring-2 disabled:opacity-50 disabled:cursor-not-allowed"
+                  />
+                  <label
+                    htmlFor={`permissions-${option.name}`}
+                    className="ml-2 text-sm font-medium text-gray-900 dark:text-dark-text"
+                  >
+                    {option.label}
+                  </label>
+                </div>
+              ))}
+            </div>
+            <div className="pl-5 pr-5 mt-4">
+              <p className="text-sm font-semibold text-gray-900 dark:text-dark-text mb-2">
+                {t("settings.roles.title", "Editor mode by Pipedrive role")}
+              </p>
+              {roleOptions.map((role) => (
+                <div
+                  key={role.name}
+                  className="flex items-center justify-between mb-2"
+                >
+                  <label
+                    htmlFor={`roles-${role.name}`}
+                    className="text-sm font-medium text-gray-900 dark:text-dark-text"
+                  >
+                    {role.label}
+                  </label>
+                  <select
+                    id={`roles-${role.name}`}
+                    value={roles[role.name]}
+                    onChange={(e) =>
+                      setRoles({
+                        ...roles,
+                        [role.name]: e.target.value as EditorMode,
+                      })
+                    }
+                    disabled={saving}
+                    className="text-sm bg-gray-100 dark:bg-dark-bg text-gray-900 dark:text-dark-text border-gray-300 dark:border-dark-border rounded disabled:opacity-50 disabled:cursor-not-allowed"
+                  >
+                    {modeOptions.map((mode) => (
+                      <option key={mode.value} value={mode.value}>
+                        {mode.label}
+                      </option>
+                    ))}
+                  </select>
+                </div>
+              ))}
+            </div>
             <div className="flex justify-start items-center mt-4 ml-5">
               <OnlyofficeButton
                 text={t("button.save", "Save")}
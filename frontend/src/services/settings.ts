@@ -20,7 +20,12 @@ import axios from "axios";
 import axiosRetry from "axios-retry";
 import AppExtensionsSDK, { Command } from "@pipedrive/app-extensions-sdk";
 
-import { SettingsResponse } from "src/types/settings";
+import {
+  DocServerStatus,
+  PermissionsPolicy,
+  RolesPolicy,
+  SettingsResponse,
+} from "src/types/settings";
 
 export const postSettings = async (
   sdk: AppExtensionsSDK,
@@ -28,6 +33,8 @@ export const postSettings = async (
   secret: string,
   header: string,
   demoEnabled = false,
+  permissions?: PermissionsPolicy,
+  roles?: RolesPolicy,
 ) => {
   const pctx = await sdk.execute(Command.GET_SIGNED_TOKEN);
   const client = axios.create({ baseURL: process.env.BACKEND_GATEWAY });
@@ -48,6 +55,8 @@ export const postSettings = async (
       doc_secret: secret,
       doc_header: header,
       demo_enabled: demoEnabled,
+      permissions,
+      roles,
     },
     timeout: 4000,
   });
@@ -102,3 +111,24 @@ export const checkSettings = async (sdk: AppExtensionsSDK) => {
     return false;
   }
 };
+
+export const getSettingsStatus = async (sdk: AppExtensionsSDK) => {
+  const pctx = await sdk.execute(Command.GET_SIGNED_TOKEN);
+  const client = axios.create({ baseURL: process.env.BACKEND_GATEWAY });
+
+  try {
+    const response = await client<DocServerStatus>({
+      method: "GET",
+      url: `/api/settings/status`,
+      headers: {
+        "Content-Type": "application/json",
+        "X-Pipedrive-App-Context": pctx.token,
+      },
+      timeout: 10000,
+    });
+
+    return response.data;
+  } catch {
+    return null;
+  }
+};
@@ -16,10 +16,38 @@
  *
  */
 
+export type PermissionsPolicy = {
+  print: boolean;
+  disable_download: boolean;
+  force_review: boolean;
+  edit_comment_author_only: boolean;
+  delete_comment_author_only: boolean;
+};
+
+export type EditorMode = "" | "edit" | "review" | "comment" | "view";
+
+export type RolesPolicy = {
+  admin: EditorMode;
+  regular: EditorMode;
+  permission_sets: Record<string, EditorMode> | null;
+};
+
 export type SettingsResponse = {
   doc_address: string;
   doc_secret: string;
   doc_header: string;
   demo_enabled: boolean;
   demo_started: string;
+  permissions: PermissionsPolicy;
+  roles: RolesPolicy;
+};
+
+export type DocServerStatus = {
+  configured: boolean;
+  demo: boolean;
+  reachable: boolean;
+  version?: string;
+  latency: number;
+  error?: string;
+  checked_at: string;
 };